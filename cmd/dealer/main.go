@@ -0,0 +1,187 @@
+// trusted dealer: splits an existing secp256k1 private key into sealed FROST
+// share bundles and imports a bundle into a running party
+//
+//	dealer split -key key.hex -parties 8801,8802,8803 -threshold 2 -passphrases passphrases.txt -out bundles
+//	dealer import -bundle bundles/8801.bundle.json -party http://127.0.0.1:8801/ -passphrase-file passphrase.txt
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"frost/internal/dealer"
+	"frost/internal/party/partyclient"
+	sigagrpc "frost/internal/sigag/rpc"
+	sss "frost/pkg/SSS"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// public is the unsealed output of a split, for sigag and auditors.
+type public struct {
+	GroupKey           sigagrpc.GroupKey           `json:"group_key"`
+	VerificationShares sigagrpc.VerificationShares `json:"verification_shares"`
+	Commitments        sss.Commitments             `json:"commitments"`
+}
+
+func main() {
+	logger := logrus.New()
+
+	if len(os.Args) < 2 {
+		logger.Fatal("usage: dealer split|import [flags]")
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "split":
+		err = split(os.Args[2:], logger)
+	case "import":
+		err = importBundle(os.Args[2:], logger)
+	default:
+		err = fmt.Errorf("unknown command %s", os.Args[1])
+	}
+
+	if err != nil {
+		logger.Fatal(err)
+	}
+}
+
+func split(args []string, logger *logrus.Logger) error {
+	flags := flag.NewFlagSet("split", flag.ExitOnError)
+	keyFile := flags.String("key", "", "file holding the hex encoded secp256k1 private key")
+	parties := flags.String("parties", "", "comma separated party ids")
+	threshold := flags.Uint("threshold", 0, "number of parties needed to sign")
+	epoch := flags.Uint("epoch", 1, "epoch the shares are key material for")
+	passphrases := flags.String("passphrases", "", "file of party=passphrase lines, one per party")
+	out := flags.String("out", ".", "directory the bundles are written to")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	encoded, err := os.ReadFile(*keyFile)
+	if err != nil {
+		return err
+	}
+
+	privateKey, err := hex.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil {
+		return fmt.Errorf("private key is not hex encoded: %w", err)
+	}
+
+	ids := strings.Split(*parties, ",")
+	deal, err := dealer.SplitSecp256k1(privateKey, *epoch, ids, *threshold)
+	if err != nil {
+		return err
+	}
+
+	secrets, err := readPassphrases(*passphrases)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(*out, 0o700); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		passphrase, ok := secrets[id]
+		if !ok {
+			return fmt.Errorf("no passphrase for %s", id)
+		}
+
+		bundle, err := deal.Seal(id, []byte(passphrase))
+		if err != nil {
+			return err
+		}
+
+		if err := writeJSON(filepath.Join(*out, id+".bundle.json"), bundle); err != nil {
+			return err
+		}
+	}
+
+	groupKey, verificationShares := deal.GroupKey()
+	if err := writeJSON(filepath.Join(*out, "group.json"), public{
+		GroupKey:           groupKey,
+		VerificationShares: verificationShares,
+		Commitments:        deal.Commitments,
+	}); err != nil {
+		return err
+	}
+
+	logger.Infof("split key %s into %d-of-%d shares for epoch %d", groupKey.GroupPublicKey, deal.Threshold, len(ids), deal.Epoch)
+	return nil
+}
+
+func importBundle(args []string, logger *logrus.Logger) error {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	bundleFile := flags.String("bundle", "", "sealed bundle written by split")
+	url := flags.String("party", "", "rpc url of the party the bundle belongs to")
+	passphraseFile := flags.String("passphrase-file", "", "file holding the bundle passphrase")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(*bundleFile)
+	if err != nil {
+		return err
+	}
+
+	var bundle dealer.Bundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return err
+	}
+
+	passphrase, err := os.ReadFile(*passphraseFile)
+	if err != nil {
+		return err
+	}
+
+	response, err := partyclient.NewFromURL(bundle.Party, *url).ImportKey(bundle, string(bytes.TrimRight(passphrase, "\r\n")))
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("party %s holds a share of %s for epoch %d", bundle.Party, response.GroupPublicKey, bundle.Epoch)
+	return nil
+}
+
+// readPassphrases parses party=passphrase lines, skipping blank lines.
+func readPassphrases(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	passphrases := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		id, passphrase, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("passphrase line without a party id")
+		}
+		passphrases[strings.TrimSpace(id)] = passphrase
+	}
+
+	return passphrases, scanner.Err()
+}
+
+func writeJSON(path string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o600)
+}
@@ -5,6 +5,7 @@ import (
 	"frost/internal/party"
 	"sync"
 
+	"github.com/rosedblabs/rosedb/v2"
 	"github.com/sirupsen/logrus"
 	"go.uber.org/zap"
 )
@@ -21,8 +22,19 @@ func main() {
 	// start nodes
 	totalNodes := 5
 	for i := 1; i <= totalNodes; i++ {
+		options := rosedb.DefaultOptions
+		options.DirPath = fmt.Sprintf("D:/codebases/Ozone/frost-golang/cmd/local/party/tmp/root_party_880%d", i)
+
+		db, err := rosedb.Open(options)
+		if err != nil {
+			panic(err)
+		}
+		defer func() {
+			_ = db.Close()
+		}()
+
 		go func(i int) {
-			if err := party.SpinNewParty(fmt.Sprintf("880%d", i), "http://localhost:8080/", true, logger); err != nil {
+			if err := party.SpinNewParty(fmt.Sprintf("880%d", i), "http://localhost:8080/", true, false, db, logger); err != nil {
 				logger.Error("failed to spin new party", zap.Error(err))
 			}
 		}(i)
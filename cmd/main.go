@@ -52,8 +52,19 @@ func main() {
 	// start nodes
 	totalNodes := 5
 	for i := 1; i <= totalNodes; i++ {
+		partyOptions := rosedb.DefaultOptions
+		partyOptions.DirPath = fmt.Sprintf("/tmp/root_party_880%d", i)
+
+		partyDB, err := rosedb.Open(partyOptions)
+		if err != nil {
+			panic(err)
+		}
+		defer func() {
+			_ = partyDB.Close()
+		}()
+
 		go func(i int) {
-			if err := party.SpinNewParty(fmt.Sprintf("880%d", i), "http://localhost:8080/", true, logger); err != nil {
+			if err := party.SpinNewParty(fmt.Sprintf("880%d", i), "http://localhost:8080/", true, false, partyDB, logger); err != nil {
 				logger.Error("failed to spin new party", zap.Error(err))
 			}
 		}(i)
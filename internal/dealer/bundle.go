@@ -0,0 +1,149 @@
+package dealer
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"frost/internal/party/dkg"
+	sss "frost/pkg/SSS"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+var ErrWrongPassphrase = errors.New("wrong passphrase or tampered bundle")
+
+// scrypt parameters for deriving a bundle key from its passphrase.
+const (
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	saltLength   = 16
+	bundleKeyLen = 32
+)
+
+// Bundle is a party's key package sealed under the party's passphrase with
+// AES-256-GCM. The party, epoch and commitments are public and bound to the
+// ciphertext, so a bundle cannot be replayed for another party or epoch.
+type Bundle struct {
+	Party       string          `json:"party"`
+	Epoch       uint            `json:"epoch"`
+	Commitments sss.Commitments `json:"commitments"`
+
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Seal encrypts a party's key package of the deal under `passphrase`.
+func (d *Deal) Seal(party string, passphrase []byte) (*Bundle, error) {
+	key, ok := d.KeyPackages[party]
+	if !ok {
+		return nil, fmt.Errorf("no key package for %s", party)
+	}
+
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("empty passphrase for %s", party)
+	}
+
+	plaintext, err := json.Marshal(key)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &Bundle{
+		Party:       party,
+		Epoch:       d.Epoch,
+		Commitments: d.Commitments,
+		Salt:        make([]byte, saltLength),
+	}
+
+	if _, err := rand.Read(bundle.Salt); err != nil {
+		return nil, err
+	}
+
+	aead, err := bundle.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(bundle.Nonce); err != nil {
+		return nil, err
+	}
+
+	additionalData, err := bundle.additionalData()
+	if err != nil {
+		return nil, err
+	}
+
+	bundle.Ciphertext = aead.Seal(nil, bundle.Nonce, plaintext, additionalData)
+	return bundle, nil
+}
+
+// Open decrypts the bundle and verifies the key package against the dealer's
+// commitments before handing it out.
+func (b *Bundle) Open(passphrase []byte) (*dkg.KeyPackage, error) {
+	aead, err := b.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("invalid bundle nonce")
+	}
+
+	additionalData, err := b.additionalData()
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := aead.Open(nil, b.Nonce, b.Ciphertext, additionalData)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+
+	var key dkg.KeyPackage
+	if err := json.Unmarshal(plaintext, &key); err != nil {
+		return nil, err
+	}
+
+	if key.ID != b.Party || key.Epoch != b.Epoch {
+		return nil, fmt.Errorf("bundle of %s in epoch %d holds the key of %s in epoch %d", b.Party, b.Epoch, key.ID, key.Epoch)
+	}
+
+	if err := Verify(&key, b.Commitments); err != nil {
+		return nil, fmt.Errorf("bundle of %s: %w", b.Party, err)
+	}
+
+	return &key, nil
+}
+
+func (b *Bundle) aead(passphrase []byte) (cipher.AEAD, error) {
+	if len(b.Salt) != saltLength {
+		return nil, fmt.Errorf("invalid bundle salt")
+	}
+
+	key, err := scrypt.Key(passphrase, b.Salt, scryptN, scryptR, scryptP, bundleKeyLen)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// additionalData binds the public part of the bundle to the ciphertext.
+func (b *Bundle) additionalData() ([]byte, error) {
+	return json.Marshal(struct {
+		Party       string          `json:"party"`
+		Epoch       uint            `json:"epoch"`
+		Commitments sss.Commitments `json:"commitments"`
+	}{b.Party, b.Epoch, b.Commitments})
+}
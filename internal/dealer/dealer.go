@@ -0,0 +1,168 @@
+// trusted dealer that moves an existing private key under threshold control
+package dealer
+
+import (
+	"encoding/hex"
+	"fmt"
+	"frost/internal/party/dkg"
+	sigagrpc "frost/internal/sigag/rpc"
+	sss "frost/pkg/SSS"
+	"frost/pkg/frost"
+	"frost/pkg/group"
+)
+
+// Deal is the outcome of splitting a key: the public Feldman commitments to
+// the dealer's polynomial and the key package of every party.
+type Deal struct {
+	Epoch       uint                       `json:"epoch"`
+	Ciphersuite string                     `json:"ciphersuite"`
+	Threshold   uint                       `json:"threshold"`
+	Commitments sss.Commitments            `json:"commitments"`
+	KeyPackages map[string]*dkg.KeyPackage `json:"-"`
+}
+
+// Split shares an existing secret among `parties` with a random degree
+// threshold-1 polynomial whose constant term is the secret, so the group key
+// stays secret·G. Identifiers are assigned exactly as in a DKG.
+func Split(cs frost.Ciphersuite, secret *group.Scalar, epoch uint, parties []string, threshold uint) (*Deal, error) {
+	g := cs.Group()
+	if secret.Group() != g {
+		return nil, fmt.Errorf("secret of group %s for ciphersuite %s", secret.Group().Name(), cs.ID())
+	}
+
+	if secret.IsZero() {
+		return nil, fmt.Errorf("cannot split a zero secret")
+	}
+
+	if threshold < 2 || threshold > uint(len(parties)) {
+		return nil, fmt.Errorf("invalid threshold %d for %d parties", threshold, len(parties))
+	}
+
+	partyMap := make(sigagrpc.Parties, len(parties))
+	for _, id := range parties {
+		if _, ok := partyMap[id]; ok {
+			return nil, fmt.Errorf("duplicate party %s", id)
+		}
+		partyMap[id] = ""
+	}
+
+	polynomial, err := sss.MakePolynomial(secret, threshold-1)
+	if err != nil {
+		return nil, err
+	}
+	commitments := polynomial.Commit()
+
+	identifiers, err := dkg.Identifiers(g, partyMap)
+	if err != nil {
+		return nil, err
+	}
+	verificationShares := make(map[string]*group.Element, len(parties))
+	for id, identifier := range identifiers {
+		verificationShares[id] = commitments.Evaluate(identifier)
+	}
+
+	keyPackages := make(map[string]*dkg.KeyPackage, len(parties))
+	for id, identifier := range identifiers {
+		signingShare := polynomial.Evaluate(identifier)
+		keyPackages[id] = &dkg.KeyPackage{
+			Epoch:              epoch,
+			Ciphersuite:        cs.ID(),
+			ID:                 id,
+			Identifier:         identifier,
+			Threshold:          threshold,
+			SigningShare:       signingShare,
+			VerificationShare:  verificationShares[id],
+			GroupPublicKey:     commitments.PublicKey(),
+			VerificationShares: verificationShares,
+		}
+	}
+
+	return &Deal{
+		Epoch:       epoch,
+		Ciphersuite: cs.ID(),
+		Threshold:   threshold,
+		Commitments: commitments,
+		KeyPackages: keyPackages,
+	}, nil
+}
+
+// SplitSecp256k1 splits a 32 byte big-endian secp256k1 private key into
+// FROST(secp256k1, SHA-256) shares. The group key is the key's public key, so
+// addresses derived from it do not change.
+func SplitSecp256k1(privateKey []byte, epoch uint, parties []string, threshold uint) (*Deal, error) {
+	secret, err := group.DeserializeScalar(group.Secp256k1, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secp256k1 private key: %w", err)
+	}
+
+	return Split(frost.Secp256k1SHA256, secret, epoch, parties, threshold)
+}
+
+// GroupKey is the public key material of the deal, as sigag publishes it for
+// an epoch.
+func (d *Deal) GroupKey() (sigagrpc.GroupKey, sigagrpc.VerificationShares) {
+	groupKey := sigagrpc.GroupKey{
+		Epoch:          d.Epoch,
+		Ciphersuite:    d.Ciphersuite,
+		Threshold:      d.Threshold,
+		GroupPublicKey: d.Commitments.PublicKey(),
+	}
+	if groupKey.GroupPublicKey.Group() == group.Secp256k1 {
+		groupKey.XOnlyPublicKey = hex.EncodeToString(frost.XOnly(groupKey.GroupPublicKey))
+	}
+
+	verificationShares := make(sigagrpc.VerificationShares)
+	for _, key := range d.KeyPackages {
+		verificationShares[key.ID] = key.VerificationShare
+	}
+
+	return groupKey, verificationShares
+}
+
+// Verify checks a key package against the dealer's Feldman commitments: the
+// signing share, every verification share and the group key must all lie on
+// the committed polynomial.
+func Verify(key *dkg.KeyPackage, commitments sss.Commitments) error {
+	if uint(len(commitments)) != key.Threshold {
+		return fmt.Errorf("expected %d commitments, got %d", key.Threshold, len(commitments))
+	}
+
+	for _, commitment := range commitments {
+		if commitment == nil || commitment.Group() != key.Identifier.Group() {
+			return fmt.Errorf("invalid commitment")
+		}
+	}
+
+	if err := commitments.Verify(sss.Share{ID: key.Identifier, Value: key.SigningShare}); err != nil {
+		return err
+	}
+
+	if !key.GroupPublicKey.Equal(commitments.PublicKey()) {
+		return fmt.Errorf("group key does not match the commitments")
+	}
+
+	if !key.VerificationShare.Equal(group.ScalarBaseMult(key.SigningShare)) {
+		return fmt.Errorf("verification share does not match the signing share")
+	}
+
+	partyMap := make(sigagrpc.Parties, len(key.VerificationShares))
+	for id := range key.VerificationShares {
+		partyMap[id] = ""
+	}
+
+	identifiers, err := dkg.Identifiers(key.Identifier.Group(), partyMap)
+	if err != nil {
+		return err
+	}
+	if !identifiers[key.ID].Equal(key.Identifier) {
+		return fmt.Errorf("identifier of %s does not match the parties", key.ID)
+	}
+
+	for id, identifier := range identifiers {
+		if !key.VerificationShares[id].Equal(commitments.Evaluate(identifier)) {
+			return fmt.Errorf("verification share of %s does not match the commitments", id)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package dealer_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestDealer(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Dealer Suite")
+}
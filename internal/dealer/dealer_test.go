@@ -0,0 +1,92 @@
+package dealer_test
+
+import (
+	"encoding/hex"
+	"frost/internal/dealer"
+	sss "frost/pkg/SSS"
+	"frost/pkg/frost"
+	"frost/pkg/group"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Dealer", func() {
+	var (
+		privateKey *btcec.PrivateKey
+		deal       *dealer.Deal
+	)
+
+	BeforeEach(func() {
+		var err error
+		privateKey, err = btcec.NewPrivateKey()
+		Expect(err).To(BeNil())
+
+		deal, err = dealer.SplitSecp256k1(privateKey.Serialize(), 1, []string{"8801", "8802", "8803"}, 2)
+		Expect(err).To(BeNil())
+	})
+
+	It("should keep the public key of the split private key", func() {
+		groupKey, verificationShares := deal.GroupKey()
+		Expect(groupKey.GroupPublicKey.Bytes()).To(Equal(privateKey.PubKey().SerializeCompressed()))
+		Expect(verificationShares).To(HaveLen(3))
+
+		secret, err := sss.Interpolate([]sss.Share{
+			{ID: deal.KeyPackages["8801"].Identifier, Value: deal.KeyPackages["8801"].SigningShare},
+			{ID: deal.KeyPackages["8803"].Identifier, Value: deal.KeyPackages["8803"].SigningShare},
+		})
+		Expect(err).To(BeNil())
+		Expect(hex.EncodeToString(secret.Bytes())).To(Equal(hex.EncodeToString(privateKey.Serialize())))
+
+		for _, key := range deal.KeyPackages {
+			Expect(dealer.Verify(key, deal.Commitments)).To(Succeed())
+		}
+	})
+
+	It("should seal a bundle that only opens for its passphrase", func() {
+		bundle, err := deal.Seal("8802", []byte("correct horse"))
+		Expect(err).To(BeNil())
+
+		key, err := bundle.Open([]byte("correct horse"))
+		Expect(err).To(BeNil())
+		Expect(key.SigningShare.Equal(deal.KeyPackages["8802"].SigningShare)).To(BeTrue())
+
+		_, err = bundle.Open([]byte("battery staple"))
+		Expect(err).To(MatchError(dealer.ErrWrongPassphrase))
+	})
+
+	It("should reject a bundle rebound to another party or epoch", func() {
+		bundle, err := deal.Seal("8802", []byte("correct horse"))
+		Expect(err).To(BeNil())
+
+		moved := *bundle
+		moved.Party = "8801"
+		_, err = moved.Open([]byte("correct horse"))
+		Expect(err).ToNot(BeNil())
+
+		moved = *bundle
+		moved.Epoch = 2
+		_, err = moved.Open([]byte("correct horse"))
+		Expect(err).ToNot(BeNil())
+	})
+
+	It("should reject a share off the committed polynomial", func() {
+		key := *deal.KeyPackages["8801"]
+		key.SigningShare = key.SigningShare.Add(group.ScalarFromInt(group.Secp256k1, 1))
+		Expect(dealer.Verify(&key, deal.Commitments)).ToNot(Succeed())
+	})
+
+	It("should refuse invalid keys and thresholds", func() {
+		_, err := dealer.SplitSecp256k1(make([]byte, 32), 1, []string{"8801", "8802"}, 2)
+		Expect(err).ToNot(BeNil())
+
+		_, err = dealer.SplitSecp256k1(privateKey.Serialize(), 1, []string{"8801", "8802"}, 3)
+		Expect(err).ToNot(BeNil())
+
+		secret, err := group.RandomScalar(group.Edwards25519)
+		Expect(err).To(BeNil())
+		_, err = dealer.Split(frost.Secp256k1SHA256, secret, 1, []string{"8801", "8802"}, 2)
+		Expect(err).ToNot(BeNil())
+	})
+})
@@ -0,0 +1,138 @@
+package dkg
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"frost/internal/party/identity"
+	"frost/pkg/group"
+)
+
+// round1Label separates signatures on round 1 packages from any other use of
+// the identity signing keys.
+const round1Label = "frost-golang/dkg/round1/v1"
+
+// SetSigningKeys records the public signing key of every party, checking that
+// the participant's own entry is `own`.
+func (p *Participant) SetSigningKeys(keys map[string]string, own string) error {
+	for id := range p.Parties {
+		key, ok := keys[id]
+		if !ok {
+			return fmt.Errorf("no signing key for %s", id)
+		}
+
+		if _, err := identity.ParseSigningKey(key); err != nil {
+			return fmt.Errorf("signing key of %s: %w", id, err)
+		}
+	}
+
+	if keys[p.ID] != own {
+		return fmt.Errorf("signing key of %s does not match its own", p.ID)
+	}
+
+	p.SigningKeys = keys
+	return nil
+}
+
+// SignedBytes is what the sender of a round 1 package signs: the package
+// without its signature.
+func (pkg Round1Package) SignedBytes() []byte {
+	pkg.Signature = nil
+	encoded, err := json.Marshal(pkg)
+	if err != nil {
+		panic(err)
+	}
+	return append([]byte(round1Label), encoded...)
+}
+
+// Digest is the hex encoded SHA-256 of the signed bytes of the package. Two
+// packages with the same digest carry the same commitments and proof.
+func (pkg Round1Package) Digest() string {
+	digest := sha256.Sum256(pkg.SignedBytes())
+	return hex.EncodeToString(digest[:])
+}
+
+// SignRound1Package signs the participant's own round 1 package, so that
+// whoever received it can show which package the participant sent.
+func (p *Participant) SignRound1Package(key *identity.Key, pkg Round1Package) (Round1Package, error) {
+	if pkg.Sender != p.ID {
+		return Round1Package{}, fmt.Errorf("cannot sign a round 1 package of %s", pkg.Sender)
+	}
+
+	pkg.Signature = key.Sign(pkg.SignedBytes())
+	return pkg, nil
+}
+
+// VerifyRound1Signature checks that a round 1 package was signed by its sender.
+func (p *Participant) VerifyRound1Signature(pkg Round1Package) error {
+	signingKey, ok := p.SigningKeys[pkg.Sender]
+	if !ok {
+		return fmt.Errorf("no signing key for %s", pkg.Sender)
+	}
+
+	if err := identity.Verify(signingKey, pkg.SignedBytes(), pkg.Signature); err != nil {
+		return fmt.Errorf("round 1 package from %s: %w", pkg.Sender, err)
+	}
+	return nil
+}
+
+// JudgeRound1 decides a mismatch between the round 1 package a dealer handed
+// sigag and the digest of the package `recipient` reported it received. The
+// recipient backs its report by showing the package, nil when it did not.
+// It returns the party at fault: the dealer when the shown package carries
+// its signature, since it then signed two different packages, and the
+// recipient when the shown package is missing, unsigned or not the one it
+// reported.
+func JudgeRound1(signingKey string, recipient string, pkg Round1Package, reported string, shown *Round1Package) (string, string) {
+	if shown == nil {
+		return recipient, fmt.Sprintf("did not show the round 1 package it reported from %s", pkg.Sender)
+	}
+
+	if shown.Epoch != pkg.Epoch || shown.Sender != pkg.Sender {
+		return recipient, fmt.Sprintf("showed a round 1 package other than the one from %s", pkg.Sender)
+	}
+
+	if shown.Digest() != reported {
+		return recipient, fmt.Sprintf("showed another round 1 package from %s than it reported", pkg.Sender)
+	}
+
+	if shown.Digest() == pkg.Digest() {
+		return recipient, fmt.Sprintf("reported the round 1 package from %s sigag holds as a different one", pkg.Sender)
+	}
+
+	if err := identity.Verify(signingKey, shown.SignedBytes(), shown.Signature); err != nil {
+		return recipient, fmt.Sprintf("showed a round 1 package %s did not sign", pkg.Sender)
+	}
+
+	return pkg.Sender, fmt.Sprintf("sent %s another round 1 package than the one it handed sigag", recipient)
+}
+
+// PublicKeys derives the group public key and the verification share of every
+// party from the round 1 packages of the qualified dealers, the way every
+// participant does in Finalize, so that sigag can tell which parties report
+// something else. A refresh adds them to the previous group key and
+// verification shares, which are nil otherwise.
+func PublicKeys(g group.Group, identifiers map[string]*group.Scalar, packages map[string]Round1Package, previousKey *group.Element, previousShares map[string]*group.Element) (*group.Element, map[string]*group.Element) {
+	groupPublicKey := g.Identity()
+	if previousKey != nil {
+		groupPublicKey = previousKey
+	}
+	for _, pkg := range packages {
+		groupPublicKey = groupPublicKey.Add(pkg.Commitments.PublicKey())
+	}
+
+	verificationShares := make(map[string]*group.Element, len(identifiers))
+	for id, identifier := range identifiers {
+		verificationShare := g.Identity()
+		if previous, ok := previousShares[id]; ok {
+			verificationShare = previous
+		}
+		for _, pkg := range packages {
+			verificationShare = verificationShare.Add(pkg.Commitments.Evaluate(identifier))
+		}
+		verificationShares[id] = verificationShare
+	}
+
+	return groupPublicKey, verificationShares
+}
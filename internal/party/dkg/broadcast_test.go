@@ -0,0 +1,107 @@
+package dkg_test
+
+import (
+	"frost/internal/party/dkg"
+	"frost/internal/party/identity"
+	"frost/pkg/frost"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Broadcast", func() {
+	for _, cs := range []frost.Ciphersuite{frost.Secp256k1SHA256, frost.Ed25519SHA512, frost.Ristretto255SHA512} {
+		cs := cs
+
+		Context("Over "+cs.ID(), func() {
+			var (
+				participants map[string]*dkg.Participant
+				keys         map[string]*identity.Key
+				packages     map[string]dkg.Round1Package
+			)
+
+			BeforeEach(func() {
+				participants = make(map[string]*dkg.Participant)
+				keys = make(map[string]*identity.Key)
+				public := make(map[string]string)
+
+				for id := range parties {
+					key, err := identity.Generate()
+					Expect(err).To(BeNil())
+					keys[id] = key
+					public[id] = key.SigningKey()
+				}
+
+				packages = make(map[string]dkg.Round1Package)
+				for id := range parties {
+					participant, err := dkg.NewParticipant(1, cs, id, parties, 2)
+					Expect(err).To(BeNil())
+					Expect(participant.SetSigningKeys(public, keys[id].SigningKey())).To(Succeed())
+					participants[id] = participant
+
+					pkg, err := participant.DkGRound1()
+					Expect(err).To(BeNil())
+					packages[id], err = participant.SignRound1Package(keys[id], pkg)
+					Expect(err).To(BeNil())
+				}
+			})
+
+			It("should only accept round 1 packages signed by their sender", func() {
+				Expect(participants["8802"].VerifyRound1Signature(packages["8801"])).To(Succeed())
+
+				forged := packages["8801"]
+				forged.Sender = "8803"
+				Expect(participants["8802"].VerifyRound1Signature(forged)).ToNot(Succeed())
+
+				_, err := participants["8801"].SignRound1Package(keys["8801"], packages["8803"])
+				Expect(err).ToNot(BeNil())
+			})
+
+			It("should blame the dealer only for a second package it signed", func() {
+				signingKey := keys["8801"].SigningKey()
+				other, err := participants["8801"].DkGRound1()
+				Expect(err).To(BeNil())
+				Expect(other.Digest()).ToNot(Equal(packages["8801"].Digest()))
+
+				unsigned := other
+				guilty, _ := dkg.JudgeRound1(signingKey, "8802", packages["8801"], unsigned.Digest(), &unsigned)
+				Expect(guilty).To(Equal("8802"))
+
+				guilty, _ = dkg.JudgeRound1(signingKey, "8802", packages["8801"], other.Digest(), nil)
+				Expect(guilty).To(Equal("8802"))
+
+				signed, err := participants["8801"].SignRound1Package(keys["8801"], other)
+				Expect(err).To(BeNil())
+				guilty, _ = dkg.JudgeRound1(signingKey, "8802", packages["8801"], "00", &signed)
+				Expect(guilty).To(Equal("8802"))
+
+				guilty, _ = dkg.JudgeRound1(signingKey, "8802", packages["8801"], signed.Digest(), &signed)
+				Expect(guilty).To(Equal("8801"))
+			})
+
+			It("should derive the public keys every participant finalizes", func() {
+				inbox := make(map[string]map[string]dkg.Round2Share)
+				for id := range participants {
+					inbox[id] = make(map[string]dkg.Round2Share)
+				}
+				for _, participant := range participants {
+					shares, err := participant.DkGRound2(packages)
+					Expect(err).To(BeNil())
+					for _, share := range shares {
+						inbox[share.Recipient][share.Sender] = share
+					}
+				}
+
+				groupPublicKey, verificationShares := dkg.PublicKeys(cs.Group(), participants["8801"].Identifiers, packages, nil, nil)
+				for id, participant := range participants {
+					key, err := participant.Finalize(packages, inbox[id])
+					Expect(err).To(BeNil())
+					Expect(key.GroupPublicKey.Equal(groupPublicKey)).To(BeTrue())
+					for party, share := range key.VerificationShares {
+						Expect(share.Equal(verificationShares[party])).To(BeTrue())
+					}
+				}
+			})
+		})
+	}
+})
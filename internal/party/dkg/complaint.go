@@ -0,0 +1,94 @@
+package dkg
+
+import (
+	"fmt"
+	sigagrpc "frost/internal/sigag/rpc"
+	sss "frost/pkg/SSS"
+	"frost/pkg/frost"
+)
+
+// Disqualify drops the given parties from the run. A resharing cannot lose a
+// dealer, since the dealers' Lagrange coefficients only add up to the previous
+// key over all of them.
+func (p *Participant) Disqualify(parties []string) error {
+	disqualified := make(map[string]bool, len(parties))
+	for _, id := range parties {
+		if id == p.ID {
+			return fmt.Errorf("party %s is disqualified from epoch %d", p.ID, p.Epoch)
+		}
+
+		if _, ok := p.Parties[id]; !ok {
+			return fmt.Errorf("cannot disqualify %s, which is not part of the dkg", id)
+		}
+
+		if p.Reshare() {
+			for _, dealer := range p.Resharing.Dealers {
+				if dealer == id {
+					return fmt.Errorf("cannot disqualify dealer %s of a resharing", id)
+				}
+			}
+		}
+
+		disqualified[id] = true
+	}
+
+	if uint(len(p.Parties)-len(disqualified)) < p.Threshold {
+		return fmt.Errorf("only %d qualified parties for threshold %d", len(p.Parties)-len(disqualified), p.Threshold)
+	}
+
+	p.Disqualified = disqualified
+	return nil
+}
+
+// RevealShare recomputes the share the participant sent to `recipient`, to be
+// published when the recipient complains about it.
+func (p *Participant) RevealShare(recipient string) (Round2Share, error) {
+	if !p.Dealer() {
+		return Round2Share{}, fmt.Errorf("party %s does not deal in epoch %d", p.ID, p.Epoch)
+	}
+
+	identifier, ok := p.Identifiers[recipient]
+	if !ok || recipient == p.ID {
+		return Round2Share{}, fmt.Errorf("party %s sent no share to %s", p.ID, recipient)
+	}
+
+	return Round2Share{
+		Epoch:     p.Epoch,
+		Sender:    p.ID,
+		Recipient: recipient,
+		Value:     p.Polynomial.Evaluate(identifier),
+	}, nil
+}
+
+// Judge decides a complaint from the accused dealer's round 1 package and the
+// share it revealed, nil when it did not reveal one. It returns the party at
+// fault: the accused when the revealed share does not match its commitments,
+// the accuser when it does.
+func Judge(cs frost.Ciphersuite, parties sigagrpc.Parties, complaint sigagrpc.DKGComplaint, pkg Round1Package, revealed *Round2Share) (string, string) {
+	if _, ok := parties[complaint.Accuser]; !ok {
+		return complaint.Accuser, "complained without being part of the dkg"
+	}
+	accuser := Identifier(cs.Group(), complaint.Accuser)
+
+	if pkg.Sender != complaint.Accused || pkg.Epoch != complaint.Epoch {
+		return complaint.Accused, "no round 1 package to check its share against"
+	}
+
+	if revealed == nil {
+		return complaint.Accused, fmt.Sprintf("did not reveal its share for %s", complaint.Accuser)
+	}
+
+	if revealed.Epoch != complaint.Epoch || revealed.Sender != complaint.Accused || revealed.Recipient != complaint.Accuser {
+		return complaint.Accused, fmt.Sprintf("revealed a share other than the one for %s", complaint.Accuser)
+	}
+
+	if revealed.Value == nil || revealed.Value.Group() != cs.Group() {
+		return complaint.Accused, fmt.Sprintf("revealed an empty share for %s", complaint.Accuser)
+	}
+
+	if err := pkg.Commitments.Verify(sss.Share{ID: accuser, Value: revealed.Value}); err != nil {
+		return complaint.Accused, fmt.Sprintf("share for %s does not match its commitments", complaint.Accuser)
+	}
+
+	return complaint.Accuser, fmt.Sprintf("complained about a valid share from %s", complaint.Accused)
+}
@@ -0,0 +1,118 @@
+package dkg_test
+
+import (
+	"frost/internal/party/dkg"
+	sigagrpc "frost/internal/sigag/rpc"
+	"frost/pkg/frost"
+	"frost/pkg/group"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Complaint", func() {
+	committee := sigagrpc.Parties{
+		"8801": "127.0.0.1:8801/",
+		"8802": "127.0.0.1:8802/",
+		"8803": "127.0.0.1:8803/",
+		"8804": "127.0.0.1:8804/",
+	}
+
+	for _, cs := range []frost.Ciphersuite{frost.Secp256k1SHA256, frost.Ed25519SHA512, frost.Ristretto255SHA512} {
+		cs := cs
+
+		Context("Over "+cs.ID(), func() {
+			var (
+				participants map[string]*dkg.Participant
+				packages     map[string]dkg.Round1Package
+				inbox        map[string]map[string]dkg.Round2Share
+				complaint    sigagrpc.DKGComplaint
+			)
+
+			BeforeEach(func() {
+				participants = make(map[string]*dkg.Participant)
+				packages = make(map[string]dkg.Round1Package)
+				inbox = make(map[string]map[string]dkg.Round2Share)
+
+				for id := range committee {
+					participant, err := dkg.NewParticipant(1, cs, id, committee, 2)
+					Expect(err).To(BeNil())
+					participants[id] = participant
+
+					packages[id], err = participant.DkGRound1()
+					Expect(err).To(BeNil())
+					inbox[id] = make(map[string]dkg.Round2Share)
+				}
+
+				for _, participant := range participants {
+					shares, err := participant.DkGRound2(packages)
+					Expect(err).To(BeNil())
+					for _, share := range shares {
+						inbox[share.Recipient][share.Sender] = share
+					}
+				}
+
+				// 8804 cheats 8801
+				share := inbox["8801"]["8804"]
+				share.Value = share.Value.Add(group.ScalarFromInt(cs.Group(), 1))
+				inbox["8801"]["8804"] = share
+
+				err := participants["8801"].VerifyRound2Share(share, packages["8804"])
+				Expect(err).ToNot(BeNil())
+				complaint = sigagrpc.DKGComplaint{Epoch: 1, Accuser: "8801", Accused: "8804", Reason: err.Error()}
+			})
+
+			It("should blame a dealer whose revealed share does not match its commitments", func() {
+				revealed := inbox["8801"]["8804"]
+				guilty, _ := dkg.Judge(cs, committee, complaint, packages["8804"], &revealed)
+				Expect(guilty).To(Equal("8804"))
+			})
+
+			It("should blame a dealer that does not reveal its share", func() {
+				guilty, _ := dkg.Judge(cs, committee, complaint, packages["8804"], nil)
+				Expect(guilty).To(Equal("8804"))
+			})
+
+			It("should blame a party complaining about a valid share", func() {
+				lie := sigagrpc.DKGComplaint{Epoch: 1, Accuser: "8802", Accused: "8803"}
+				revealed, err := participants["8803"].RevealShare("8802")
+				Expect(err).To(BeNil())
+				Expect(revealed.Value.Equal(inbox["8802"]["8803"].Value)).To(BeTrue())
+
+				guilty, _ := dkg.Judge(cs, committee, lie, packages["8803"], &revealed)
+				Expect(guilty).To(Equal("8802"))
+			})
+
+			It("should finish with the qualified parties once the cheater is disqualified", func() {
+				keys := make(map[string]*dkg.KeyPackage)
+				for id, participant := range participants {
+					if id == "8804" {
+						continue
+					}
+
+					Expect(participant.Disqualify([]string{"8804"})).To(Succeed())
+					key, err := participant.Finalize(packages, inbox[id])
+					Expect(err).To(BeNil())
+					keys[id] = key
+				}
+
+				groupPublicKey := cs.Group().Identity()
+				for id, pkg := range packages {
+					if id != "8804" {
+						groupPublicKey = groupPublicKey.Add(pkg.Commitments.PublicKey())
+					}
+				}
+
+				for _, key := range keys {
+					Expect(key.GroupPublicKey.Equal(groupPublicKey)).To(BeTrue())
+					Expect(key.VerificationShares["8802"].Equal(keys["8802"].VerificationShare)).To(BeTrue())
+				}
+			})
+
+			It("should refuse to disqualify itself or drop below the threshold", func() {
+				Expect(participants["8801"].Disqualify([]string{"8801"})).ToNot(Succeed())
+				Expect(participants["8801"].Disqualify([]string{"8802", "8803", "8804"})).ToNot(Succeed())
+			})
+		})
+	}
+})
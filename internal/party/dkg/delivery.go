@@ -0,0 +1,37 @@
+package dkg
+
+import "sort"
+
+// Deliveries lists the qualified dealers a participant still waits for.
+type Deliveries struct {
+	Epoch uint `json:"epoch"`
+	// Round1 are the dealers whose round 1 package has not arrived.
+	Round1 []string `json:"round1"`
+	// Round2 are the dealers whose round 2 share has not arrived.
+	Round2 []string `json:"round2"`
+}
+
+// Done reports whether every package and share has arrived.
+func (d Deliveries) Done() bool {
+	return len(d.Round1) == 0 && len(d.Round2) == 0
+}
+
+// Awaiting compares the packages and shares the participant received with the
+// qualified dealers it expects them from. Its own package counts, its own
+// share is never sent.
+func (p *Participant) Awaiting(packages map[string]Round1Package, shares map[string]Round2Share) Deliveries {
+	deliveries := Deliveries{Epoch: p.Epoch, Round1: []string{}, Round2: []string{}}
+	for _, id := range p.dealers() {
+		if _, ok := packages[id]; !ok {
+			deliveries.Round1 = append(deliveries.Round1, id)
+		}
+
+		if _, ok := shares[id]; !ok && id != p.ID {
+			deliveries.Round2 = append(deliveries.Round2, id)
+		}
+	}
+
+	sort.Strings(deliveries.Round1)
+	sort.Strings(deliveries.Round2)
+	return deliveries
+}
@@ -1,15 +1,665 @@
 package dkg
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	sigagrpc "frost/internal/sigag/rpc"
 	sss "frost/pkg/SSS"
-	"math/big"
-	"math/rand"
+	"frost/pkg/frost"
+	"frost/pkg/group"
 )
 
-func DkGRound1(Parties sigagrpc.Parties, Threshold uint,
-) error {
-	a0_secret := new(big.Int).Rand(rand.New(rand.NewSource(9)), big.NewInt(256))
-	_ = sss.MakePolynomial(a0_secret, Threshold-1)
+var ErrInvalidProof = errors.New("invalid proof of knowledge")
+
+// Proof is a Schnorr proof of knowledge of the constant term a0 of a party's
+// secret polynomial, bound to the party's identifier and the DKG context.
+type Proof struct {
+	R  *group.Element `json:"r"`
+	Mu *group.Scalar  `json:"mu"`
+}
+
+// Round1Package is broadcast by every party to every other party in round 1.
+// The sender signs it, so a party that received it can show what was sent.
+type Round1Package struct {
+	Epoch       uint            `json:"epoch"`
+	Sender      string          `json:"sender"`
+	Commitments sss.Commitments `json:"commitments"`
+	Proof       Proof           `json:"proof"`
+	Signature   []byte          `json:"signature,omitempty"`
+}
+
+// Round2Share is the secret share f_sender(recipient) sent privately to a
+// single party in round 2.
+type Round2Share struct {
+	Epoch     uint          `json:"epoch"`
+	Sender    string        `json:"sender"`
+	Recipient string        `json:"recipient"`
+	Value     *group.Scalar `json:"value"`
+}
+
+// KeyPackage is a party's long-lived key material for an epoch.
+type KeyPackage struct {
+	Epoch       uint          `json:"epoch"`
+	Ciphersuite string        `json:"ciphersuite"`
+	ID          string        `json:"id"`
+	Identifier  *group.Scalar `json:"identifier"`
+	Threshold   uint          `json:"threshold"`
+
+	SigningShare       *group.Scalar             `json:"signing_share"`
+	VerificationShare  *group.Element            `json:"verification_share"`
+	GroupPublicKey     *group.Element            `json:"group_public_key"`
+	VerificationShares map[string]*group.Element `json:"verification_shares"`
+}
+
+// Resharing hands the key of a previous epoch to a new committee. Every
+// dealer is an old holder that re-shares λ_i·s_i under the new threshold, so
+// the new shares interpolate to the same group secret.
+type Resharing struct {
+	PreviousParties   sigagrpc.Parties `json:"previous_parties"`
+	PreviousThreshold uint             `json:"previous_threshold"`
+	Dealers           []string         `json:"dealers"`
+
+	// GroupPublicKey and the dealers' VerificationShares of the previous
+	// epoch let new members check what the dealers re-share.
+	GroupPublicKey     *group.Element            `json:"group_public_key"`
+	VerificationShares map[string]*group.Element `json:"verification_shares"`
+}
+
+// Participant is a party's private state for a single DKG run.
+type Participant struct {
+	Epoch       uint
+	Ciphersuite frost.Ciphersuite
+	ID          string
+	Parties     sigagrpc.Parties
+	Threshold   uint
+
+	Identifiers map[string]*group.Scalar
+	Polynomial  sss.Polynomial
+
+	// Previous is the key package being refreshed or re-shared, nil when
+	// the run generates a new group key or the party is a new member.
+	Previous *KeyPackage
+	// Resharing is set when the run hands a previous key to a new committee.
+	Resharing *Resharing
+	// Disqualified are the parties sigag dropped from the run. Their
+	// packages and shares no longer count and they get no shares.
+	Disqualified map[string]bool
+	// IdentityKeys are the public identity keys round 2 shares are sealed to.
+	IdentityKeys map[string]string
+	// SigningKeys are the public signing keys round 1 packages are signed
+	// with.
+	SigningKeys map[string]string
+}
+
+// participantJSON is how a Participant is persisted between the steps of its
+// run, with the ciphersuite named by its ID.
+type participantJSON struct {
+	Epoch       uint             `json:"epoch"`
+	Ciphersuite string           `json:"ciphersuite"`
+	ID          string           `json:"id"`
+	Parties     sigagrpc.Parties `json:"parties"`
+	Threshold   uint             `json:"threshold"`
+
+	Identifiers map[string]*group.Scalar `json:"identifiers"`
+	Polynomial  sss.Polynomial           `json:"polynomial"`
+
+	Previous     *KeyPackage       `json:"previous"`
+	Resharing    *Resharing        `json:"resharing"`
+	Disqualified map[string]bool   `json:"disqualified"`
+	IdentityKeys map[string]string `json:"identity_keys"`
+	SigningKeys  map[string]string `json:"signing_keys"`
+}
+
+func (p *Participant) MarshalJSON() ([]byte, error) {
+	return json.Marshal(participantJSON{
+		Epoch:        p.Epoch,
+		Ciphersuite:  p.Ciphersuite.ID(),
+		ID:           p.ID,
+		Parties:      p.Parties,
+		Threshold:    p.Threshold,
+		Identifiers:  p.Identifiers,
+		Polynomial:   p.Polynomial,
+		Previous:     p.Previous,
+		Resharing:    p.Resharing,
+		Disqualified: p.Disqualified,
+		IdentityKeys: p.IdentityKeys,
+		SigningKeys:  p.SigningKeys,
+	})
+}
+
+func (p *Participant) UnmarshalJSON(data []byte) error {
+	var decoded participantJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	cs, err := frost.CiphersuiteByID(decoded.Ciphersuite)
+	if err != nil {
+		return err
+	}
+
+	*p = Participant{
+		Epoch:        decoded.Epoch,
+		Ciphersuite:  cs,
+		ID:           decoded.ID,
+		Parties:      decoded.Parties,
+		Threshold:    decoded.Threshold,
+		Identifiers:  decoded.Identifiers,
+		Polynomial:   decoded.Polynomial,
+		Previous:     decoded.Previous,
+		Resharing:    decoded.Resharing,
+		Disqualified: decoded.Disqualified,
+		IdentityKeys: decoded.IdentityKeys,
+		SigningKeys:  decoded.SigningKeys,
+	}
+	return nil
+}
+
+// Context binds round 1 proofs to a single DKG run.
+func Context(epoch uint) []byte {
+	return []byte(fmt.Sprintf("frost-golang/dkg/epoch/%d", epoch))
+}
+
+// NewParticipant samples a fresh secret polynomial of degree threshold-1 over
+// the ciphersuite's group for the party `id` among `parties`.
+func NewParticipant(epoch uint, cs frost.Ciphersuite, id string, parties sigagrpc.Parties, threshold uint) (*Participant, error) {
+	if _, ok := parties[id]; !ok {
+		return nil, fmt.Errorf("party %s is not part of the dkg", id)
+	}
+
+	if threshold == 0 || threshold > uint(len(parties)) {
+		return nil, fmt.Errorf("invalid threshold %d for %d parties", threshold, len(parties))
+	}
+
+	secret, err := group.RandomScalar(cs.Group())
+	if err != nil {
+		return nil, err
+	}
+
+	polynomial, err := sss.MakePolynomial(secret, threshold-1)
+	if err != nil {
+		return nil, err
+	}
+
+	identifiers, err := Identifiers(cs.Group(), parties)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Participant{
+		Epoch:       epoch,
+		Ciphersuite: cs,
+		ID:          id,
+		Parties:     parties,
+		Threshold:   threshold,
+		Identifiers: identifiers,
+		Polynomial:  polynomial,
+	}, nil
+}
+
+// NewRefreshParticipant samples a polynomial with a zero constant term for a
+// proactive refresh of `previous`. The refreshed shares still interpolate to
+// the same group secret, but no longer combine with the shares they replace.
+// Refreshing keeps the party set, threshold and ciphersuite of the key.
+func NewRefreshParticipant(epoch uint, previous *KeyPackage, parties sigagrpc.Parties) (*Participant, error) {
+	if epoch <= previous.Epoch {
+		return nil, fmt.Errorf("cannot refresh the key of epoch %d in epoch %d", previous.Epoch, epoch)
+	}
+
+	if len(parties) != len(previous.VerificationShares) {
+		return nil, fmt.Errorf("refresh needs the %d parties of epoch %d, got %d", len(previous.VerificationShares), previous.Epoch, len(parties))
+	}
+
+	for id := range parties {
+		if _, ok := previous.VerificationShares[id]; !ok {
+			return nil, fmt.Errorf("party %s did not hold a share in epoch %d", id, previous.Epoch)
+		}
+	}
+
+	cs, err := frost.CiphersuiteByID(previous.Ciphersuite)
+	if err != nil {
+		return nil, err
+	}
+
+	participant, err := NewParticipant(epoch, cs, previous.ID, parties, previous.Threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	participant.Polynomial[0] = group.ScalarFromInt(cs.Group(), 0)
+	participant.Previous = previous
+	return participant, nil
+}
+
+// NewResharingParticipant joins the committee that takes over a previous key.
+// Dealers pass their key package of the previous epoch and deal λ_i·s_i, new
+// members pass nil and only receive shares.
+func NewResharingParticipant(epoch uint, cs frost.Ciphersuite, id string, parties sigagrpc.Parties, threshold uint, resharing *Resharing, previous *KeyPackage) (*Participant, error) {
+	g := cs.Group()
+	if resharing.GroupPublicKey == nil || resharing.GroupPublicKey.Group() != g {
+		return nil, fmt.Errorf("resharing without a %s group key", g.Name())
+	}
+
+	if uint(len(resharing.Dealers)) < resharing.PreviousThreshold {
+		return nil, fmt.Errorf("resharing needs %d dealers, got %d", resharing.PreviousThreshold, len(resharing.Dealers))
+	}
+
+	previousIdentifiers, err := Identifiers(g, resharing.PreviousParties)
+	if err != nil {
+		return nil, err
+	}
+
+	dealers := make(map[string]bool, len(resharing.Dealers))
+	for _, dealer := range resharing.Dealers {
+		if dealers[dealer] {
+			return nil, fmt.Errorf("duplicate dealer %s", dealer)
+		}
+		dealers[dealer] = true
+
+		if _, ok := parties[dealer]; !ok {
+			return nil, fmt.Errorf("dealer %s is not part of the new committee", dealer)
+		}
+
+		if _, ok := previousIdentifiers[dealer]; !ok {
+			return nil, fmt.Errorf("dealer %s held no share of the previous key", dealer)
+		}
+
+		if share := resharing.VerificationShares[dealer]; share == nil || share.Group() != g {
+			return nil, fmt.Errorf("missing verification share of dealer %s", dealer)
+		}
+	}
+
+	participant, err := NewParticipant(epoch, cs, id, parties, threshold)
+	if err != nil {
+		return nil, err
+	}
+	participant.Resharing = resharing
+
+	if previous == nil {
+		if dealers[id] {
+			return nil, fmt.Errorf("dealer %s has no key to re-share", id)
+		}
+		return participant, nil
+	}
+
+	if !dealers[id] {
+		return nil, fmt.Errorf("party %s is not a dealer", id)
+	}
+
+	if previous.Ciphersuite != cs.ID() || !previous.GroupPublicKey.Equal(resharing.GroupPublicKey) || !previous.VerificationShare.Equal(resharing.VerificationShares[id]) {
+		return nil, fmt.Errorf("resharing does not match the key of epoch %d", previous.Epoch)
+	}
+
+	lambda, err := participant.dealerCoefficient(id)
+	if err != nil {
+		return nil, err
+	}
+
+	participant.Polynomial[0] = lambda.Mul(previous.SigningShare)
+	participant.Previous = previous
+	return participant, nil
+}
+
+// Refresh reports whether the run refreshes an existing key.
+func (p *Participant) Refresh() bool {
+	return p.Previous != nil && p.Resharing == nil
+}
+
+// Reshare reports whether the run hands a previous key to a new committee.
+func (p *Participant) Reshare() bool {
+	return p.Resharing != nil
+}
+
+// Dealer reports whether the participant deals shares in this run. Everyone
+// deals, except new members of a resharing.
+func (p *Participant) Dealer() bool {
+	return !p.Reshare() || p.Previous != nil
+}
+
+// dealers lists the qualified parties expected to send round 1 packages and
+// shares.
+func (p *Participant) dealers() []string {
+	if p.Reshare() {
+		return p.Resharing.Dealers
+	}
+
+	dealers := make([]string, 0, len(p.Parties))
+	for id := range p.Parties {
+		if !p.Disqualified[id] {
+			dealers = append(dealers, id)
+		}
+	}
+	return dealers
+}
+
+// qualified drops the round 1 packages of disqualified parties.
+func (p *Participant) qualified(packages map[string]Round1Package) map[string]Round1Package {
+	qualified := make(map[string]Round1Package, len(packages))
+	for id, pkg := range packages {
+		if !p.Disqualified[id] {
+			qualified[id] = pkg
+		}
+	}
+	return qualified
+}
+
+// dealerCoefficient is the Lagrange coefficient of a dealer's previous
+// identifier among all dealers.
+func (p *Participant) dealerCoefficient(dealer string) (*group.Scalar, error) {
+	previousIdentifiers, err := Identifiers(p.Ciphersuite.Group(), p.Resharing.PreviousParties)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]*group.Scalar, 0, len(p.Resharing.Dealers))
+	for _, id := range p.Resharing.Dealers {
+		ids = append(ids, previousIdentifiers[id])
+	}
+
+	return sss.LagrangeCoefficient(previousIdentifiers[dealer], ids)
+}
+
+// Identifier returns the party's own scalar identifier.
+func (p *Participant) Identifier() *group.Scalar {
+	return p.Identifiers[p.ID]
+}
+
+// DkGRound1 computes the Feldman commitments to the participant's polynomial
+// together with a proof of knowledge of its secret. A refresh commits to a
+// zero secret and a resharing to λ_i·s_i, which is checked against the
+// previous verification share instead of a proof.
+func (p *Participant) DkGRound1() (Round1Package, error) {
+	if !p.Dealer() {
+		return Round1Package{}, fmt.Errorf("party %s does not deal in epoch %d", p.ID, p.Epoch)
+	}
+
+	if p.Refresh() || p.Reshare() {
+		return Round1Package{
+			Epoch:       p.Epoch,
+			Sender:      p.ID,
+			Commitments: p.Polynomial.Commit(),
+		}, nil
+	}
+
+	k, err := group.RandomScalar(p.Ciphersuite.Group())
+	if err != nil {
+		return Round1Package{}, err
+	}
+
+	commitments := p.Polynomial.Commit()
+	R := group.ScalarBaseMult(k)
+	c := challenge(p.Ciphersuite, p.Identifier(), Context(p.Epoch), commitments.PublicKey(), R)
+
+	// μ = k + a0·c
+	mu := k.Add(p.Polynomial[0].Mul(c))
+
+	return Round1Package{
+		Epoch:       p.Epoch,
+		Sender:      p.ID,
+		Commitments: commitments,
+		Proof:       Proof{R: R, Mu: mu},
+	}, nil
+}
+
+// Equal reports whether both packages carry the same commitments and proof.
+func (pkg Round1Package) Equal(other Round1Package) bool {
+	if pkg.Epoch != other.Epoch || pkg.Sender != other.Sender || len(pkg.Commitments) != len(other.Commitments) {
+		return false
+	}
+
+	for i := range pkg.Commitments {
+		if !pkg.Commitments[i].Equal(other.Commitments[i]) {
+			return false
+		}
+	}
+
+	return pkg.Proof.R.Equal(other.Proof.R) && pkg.Proof.Mu.Equal(other.Proof.Mu)
+}
+
+// VerifyRound1Package checks that a package from another party belongs to this
+// DKG run and carries a valid proof of knowledge.
+func (p *Participant) VerifyRound1Package(pkg Round1Package) error {
+	if pkg.Epoch != p.Epoch {
+		return fmt.Errorf("round 1 package for epoch %d, expected %d", pkg.Epoch, p.Epoch)
+	}
+
+	identifier, ok := p.Identifiers[pkg.Sender]
+	if !ok {
+		return fmt.Errorf("round 1 package from unknown party %s", pkg.Sender)
+	}
+
+	if uint(len(pkg.Commitments)) != p.Threshold {
+		return fmt.Errorf("expected %d commitments from %s, got %d", p.Threshold, pkg.Sender, len(pkg.Commitments))
+	}
+
+	for i, commitment := range pkg.Commitments {
+		if commitment == nil || commitment.Group() != p.Ciphersuite.Group() {
+			return fmt.Errorf("invalid commitment from %s", pkg.Sender)
+		}
+
+		// a refresh must not move the group key, so its constant term is zero
+		if i == 0 && p.Refresh() {
+			if !commitment.IsIdentity() {
+				return fmt.Errorf("refresh package from %s commits to a non-zero secret", pkg.Sender)
+			}
+			continue
+		}
+
+		if commitment.IsIdentity() {
+			return fmt.Errorf("invalid commitment from %s", pkg.Sender)
+		}
+	}
+
+	if p.Refresh() {
+		return nil
+	}
+
+	if p.Reshare() {
+		return p.verifyDealerCommitment(pkg)
+	}
+
+	return VerifyProof(p.Ciphersuite, identifier, Context(p.Epoch), pkg.Commitments.PublicKey(), pkg.Proof)
+}
+
+// verifyDealerCommitment checks that a dealer commits to λ_i·Y_i, its
+// weighted verification share of the previous key.
+func (p *Participant) verifyDealerCommitment(pkg Round1Package) error {
+	isDealer := false
+	for _, dealer := range p.Resharing.Dealers {
+		isDealer = isDealer || dealer == pkg.Sender
+	}
+	if !isDealer {
+		return fmt.Errorf("round 1 package from %s, which does not deal", pkg.Sender)
+	}
+
+	lambda, err := p.dealerCoefficient(pkg.Sender)
+	if err != nil {
+		return err
+	}
+
+	if !pkg.Commitments[0].Equal(p.Resharing.VerificationShares[pkg.Sender].ScalarMult(lambda)) {
+		return fmt.Errorf("dealer %s does not re-share its previous share", pkg.Sender)
+	}
+
+	return nil
+}
+
+// DkGRound2 evaluates the participant's polynomial for every other qualified
+// party. It requires a verified round 1 package from every qualified dealer,
+// including its own. New members of a resharing have nothing to send.
+func (p *Participant) DkGRound2(packages map[string]Round1Package) ([]Round2Share, error) {
+	if err := p.checkRound1Packages(p.qualified(packages)); err != nil {
+		return nil, err
+	}
+
+	if !p.Dealer() {
+		return nil, nil
+	}
+
+	shares := make([]Round2Share, 0, len(p.Parties)-1)
+	for id := range p.Parties {
+		if id == p.ID || p.Disqualified[id] {
+			continue
+		}
+
+		shares = append(shares, Round2Share{
+			Epoch:     p.Epoch,
+			Sender:    p.ID,
+			Recipient: id,
+			Value:     p.Polynomial.Evaluate(p.Identifiers[id]),
+		})
+	}
+
+	return shares, nil
+}
+
+// VerifyRound2Share checks a received share against the sender's round 1
+// commitments.
+func (p *Participant) VerifyRound2Share(share Round2Share, sender Round1Package) error {
+	if share.Epoch != p.Epoch {
+		return fmt.Errorf("round 2 share for epoch %d, expected %d", share.Epoch, p.Epoch)
+	}
+
+	if share.Recipient != p.ID {
+		return fmt.Errorf("round 2 share addressed to %s", share.Recipient)
+	}
+
+	if share.Sender != sender.Sender {
+		return fmt.Errorf("round 2 share from %s checked against commitments of %s", share.Sender, sender.Sender)
+	}
+
+	if share.Value == nil || share.Value.Group() != p.Ciphersuite.Group() {
+		return fmt.Errorf("round 2 share from %s has no value", share.Sender)
+	}
+
+	return sender.Commitments.Verify(sss.Share{ID: p.Identifier(), Value: share.Value})
+}
+
+// Finalize derives the participant's long-lived signing share s_i = Σ_j f_j(i),
+// the verification shares Y_j = s_j·G of every party and the group public key
+// Y = Σ_j C_j0 from the round 1 packages and round 2 shares of the qualified
+// dealers. A refresh adds the sums to the previous shares and keeps the group
+// key, a resharing sums over the dealers only and must reproduce the previous
+// key.
+func (p *Participant) Finalize(packages map[string]Round1Package, shares map[string]Round2Share) (*KeyPackage, error) {
+	packages = p.qualified(packages)
+	if err := p.checkRound1Packages(packages); err != nil {
+		return nil, err
+	}
+
+	signingShare := group.ScalarFromInt(p.Ciphersuite.Group(), 0)
+	if p.Dealer() {
+		signingShare = p.Polynomial.Evaluate(p.Identifier())
+	}
+	if p.Refresh() {
+		signingShare = signingShare.Add(p.Previous.SigningShare)
+	}
+	if missing := p.Awaiting(packages, shares).Round2; len(missing) > 0 {
+		return nil, fmt.Errorf("missing round 2 shares from %v", missing)
+	}
+	for _, id := range p.dealers() {
+		if id == p.ID {
+			continue
+		}
+
+		share := shares[id]
+		if err := p.VerifyRound2Share(share, packages[id]); err != nil {
+			return nil, fmt.Errorf("invalid round 2 share from %s: %w", id, err)
+		}
+
+		signingShare = signingShare.Add(share.Value)
+	}
+
+	groupPublicKey := p.Ciphersuite.Group().Identity()
+	if p.Refresh() {
+		groupPublicKey = p.Previous.GroupPublicKey
+	}
+	for _, pkg := range packages {
+		groupPublicKey = groupPublicKey.Add(pkg.Commitments.PublicKey())
+	}
+
+	if p.Reshare() && !groupPublicKey.Equal(p.Resharing.GroupPublicKey) {
+		return nil, fmt.Errorf("re-shared key %s does not match the previous group key", groupPublicKey)
+	}
+
+	verificationShares := make(map[string]*group.Element, len(p.Parties))
+	for id, identifier := range p.Identifiers {
+		verificationShare := p.Ciphersuite.Group().Identity()
+		if p.Refresh() {
+			verificationShare = p.Previous.VerificationShares[id]
+		}
+		for _, pkg := range packages {
+			verificationShare = verificationShare.Add(pkg.Commitments.Evaluate(identifier))
+		}
+		verificationShares[id] = verificationShare
+	}
+
+	verificationShare := group.ScalarBaseMult(signingShare)
+	if !verificationShare.Equal(verificationShares[p.ID]) {
+		return nil, fmt.Errorf("signing share does not match commitments")
+	}
+
+	return &KeyPackage{
+		Epoch:              p.Epoch,
+		Ciphersuite:        p.Ciphersuite.ID(),
+		ID:                 p.ID,
+		Identifier:         p.Identifier(),
+		Threshold:          p.Threshold,
+		SigningShare:       signingShare,
+		VerificationShare:  verificationShare,
+		GroupPublicKey:     groupPublicKey,
+		VerificationShares: verificationShares,
+	}, nil
+}
+
+func (p *Participant) checkRound1Packages(packages map[string]Round1Package) error {
+	if missing := p.Awaiting(packages, nil).Round1; len(missing) > 0 {
+		return fmt.Errorf("missing round 1 packages from %v", missing)
+	}
+
+	dealers := p.dealers()
+	for _, id := range dealers {
+		pkg := packages[id]
+		if err := p.VerifyRound1Package(pkg); err != nil {
+			return fmt.Errorf("invalid round 1 package from %s: %w", id, err)
+		}
+	}
+
+	if len(packages) != len(dealers) {
+		return fmt.Errorf("received round 1 packages from parties outside the dkg")
+	}
+
 	return nil
 }
+
+// VerifyProof checks μ·G - c·C_0 == R.
+func VerifyProof(cs frost.Ciphersuite, identifier *group.Scalar, context []byte, publicKey *group.Element, proof Proof) error {
+	if proof.R == nil || proof.Mu == nil || publicKey == nil {
+		return ErrInvalidProof
+	}
+
+	g := cs.Group()
+	if proof.R.Group() != g || proof.Mu.Group() != g || publicKey.Group() != g {
+		return ErrInvalidProof
+	}
+
+	c := challenge(cs, identifier, context, publicKey, proof.R)
+	R := group.ScalarBaseMult(proof.Mu).Sub(publicKey.ScalarMult(c))
+
+	if !R.Equal(proof.R) {
+		return ErrInvalidProof
+	}
+
+	return nil
+}
+
+// challenge is c = HDKG(identifier || context || C_0 || R).
+func challenge(cs frost.Ciphersuite, identifier *group.Scalar, context []byte, publicKey, R *group.Element) *group.Scalar {
+	var m []byte
+	m = append(m, identifier.Bytes()...)
+	m = append(m, context...)
+	m = append(m, publicKey.Bytes()...)
+	m = append(m, R.Bytes()...)
+	return cs.HDKG(m)
+}
@@ -0,0 +1,386 @@
+package dkg_test
+
+import (
+	"encoding/json"
+	"frost/internal/party/dkg"
+	sigagrpc "frost/internal/sigag/rpc"
+	sss "frost/pkg/SSS"
+	"frost/pkg/frost"
+	"frost/pkg/group"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var parties = sigagrpc.Parties{
+	"8801": "127.0.0.1:8801/",
+	"8802": "127.0.0.1:8802/",
+	"8803": "127.0.0.1:8803/",
+}
+
+var _ = Describe("Dkg", func() {
+	for _, cs := range []frost.Ciphersuite{frost.Secp256k1SHA256, frost.Ed25519SHA512, frost.Ristretto255SHA512} {
+		cs := cs
+
+		Context("Over "+cs.ID(), func() {
+			var (
+				alice, bob *dkg.Participant
+				pkg        dkg.Round1Package
+			)
+
+			BeforeEach(func() {
+				var err error
+				alice, err = dkg.NewParticipant(1, cs, "8801", parties, 2)
+				Expect(err).To(BeNil())
+
+				bob, err = dkg.NewParticipant(1, cs, "8802", parties, 2)
+				Expect(err).To(BeNil())
+
+				pkg, err = alice.DkGRound1()
+				Expect(err).To(BeNil())
+			})
+
+			Context("While running round 1", func() {
+				It("should assign distinct identifiers independent of map order", func() {
+					Expect(alice.Identifiers).To(Equal(bob.Identifiers))
+					Expect(alice.Identifier().Equal(bob.Identifier())).To(BeFalse())
+				})
+
+				It("should reject parties outside the party list and bad thresholds", func() {
+					_, err := dkg.NewParticipant(1, cs, "9999", parties, 2)
+					Expect(err).ToNot(BeNil())
+
+					_, err = dkg.NewParticipant(1, cs, "8801", parties, 4)
+					Expect(err).ToNot(BeNil())
+				})
+
+				It("should accept an honest round 1 package", func() {
+					Expect(pkg.Commitments).To(HaveLen(2))
+					Expect(bob.VerifyRound1Package(pkg)).To(Succeed())
+				})
+
+				It("should reject a package whose proof is bound to another sender", func() {
+					pkg.Sender = "8803"
+					Expect(bob.VerifyRound1Package(pkg)).To(MatchError(dkg.ErrInvalidProof))
+				})
+
+				It("should reject a package replayed into another epoch", func() {
+					other, err := dkg.NewParticipant(2, cs, "8802", parties, 2)
+					Expect(err).To(BeNil())
+
+					pkg.Epoch = 2
+					Expect(other.VerifyRound1Package(pkg)).To(MatchError(dkg.ErrInvalidProof))
+				})
+
+				It("should reject a package with a forged proof", func() {
+					pkg.Proof.Mu = pkg.Proof.Mu.Add(group.ScalarFromInt(cs.Group(), 1))
+					Expect(bob.VerifyRound1Package(pkg)).To(MatchError(dkg.ErrInvalidProof))
+				})
+
+				It("should reject a package committing to a secret it does not know", func() {
+					pkg.Commitments[0] = group.ScalarBaseMult(group.ScalarFromInt(cs.Group(), 42))
+					Expect(bob.VerifyRound1Package(pkg)).To(MatchError(dkg.ErrInvalidProof))
+				})
+
+				It("should reject a package with the wrong number of commitments", func() {
+					pkg.Commitments = pkg.Commitments[:1]
+					Expect(bob.VerifyRound1Package(pkg)).ToNot(Succeed())
+				})
+			})
+
+			Context("While running round 2", func() {
+				var (
+					participants map[string]*dkg.Participant
+					packages     map[string]dkg.Round1Package
+					inbox        map[string]map[string]dkg.Round2Share
+				)
+
+				BeforeEach(func() {
+					participants = make(map[string]*dkg.Participant)
+					packages = make(map[string]dkg.Round1Package)
+					inbox = make(map[string]map[string]dkg.Round2Share)
+
+					for id := range parties {
+						participant, err := dkg.NewParticipant(1, cs, id, parties, 2)
+						Expect(err).To(BeNil())
+						participants[id] = participant
+
+						packages[id], err = participant.DkGRound1()
+						Expect(err).To(BeNil())
+						inbox[id] = make(map[string]dkg.Round2Share)
+					}
+
+					for _, participant := range participants {
+						shares, err := participant.DkGRound2(packages)
+						Expect(err).To(BeNil())
+						Expect(shares).To(HaveLen(2))
+
+						for _, share := range shares {
+							inbox[share.Recipient][share.Sender] = share
+						}
+					}
+				})
+
+				It("should derive signing shares for a single group key", func() {
+					keys := make([]*dkg.KeyPackage, 0, len(participants))
+					for id, participant := range participants {
+						key, err := participant.Finalize(packages, inbox[id])
+						Expect(err).To(BeNil())
+						keys = append(keys, key)
+					}
+
+					for _, key := range keys {
+						Expect(key.GroupPublicKey.Equal(keys[0].GroupPublicKey)).To(BeTrue())
+						Expect(key.VerificationShare.Equal(keys[0].VerificationShares[key.ID])).To(BeTrue())
+					}
+
+					secret, err := sss.Interpolate([]sss.Share{
+						{ID: keys[0].Identifier, Value: keys[0].SigningShare},
+						{ID: keys[1].Identifier, Value: keys[1].SigningShare},
+					})
+					Expect(err).To(BeNil())
+					Expect(group.ScalarBaseMult(secret).Equal(keys[0].GroupPublicKey)).To(BeTrue())
+				})
+
+				It("should finish from a participant restored from json", func() {
+					Expect(participants["8801"].Disqualify(nil)).To(Succeed())
+
+					data, err := json.Marshal(participants["8801"])
+					Expect(err).To(BeNil())
+
+					var restored dkg.Participant
+					Expect(json.Unmarshal(data, &restored)).To(Succeed())
+					Expect(restored.Ciphersuite.ID()).To(Equal(cs.ID()))
+
+					key, err := participants["8801"].Finalize(packages, inbox["8801"])
+					Expect(err).To(BeNil())
+
+					restoredKey, err := restored.Finalize(packages, inbox["8801"])
+					Expect(err).To(BeNil())
+					Expect(restoredKey.SigningShare.Equal(key.SigningShare)).To(BeTrue())
+					Expect(restoredKey.GroupPublicKey.Equal(key.GroupPublicKey)).To(BeTrue())
+				})
+
+				It("should refuse to start round 2 without every round 1 package", func() {
+					delete(packages, "8803")
+					_, err := participants["8801"].DkGRound2(packages)
+					Expect(err).ToNot(BeNil())
+				})
+
+				It("should reject a share that does not match the sender's commitments", func() {
+					share := inbox["8801"]["8802"]
+					share.Value = share.Value.Add(group.ScalarFromInt(cs.Group(), 1))
+					Expect(participants["8801"].VerifyRound2Share(share, packages["8802"])).To(MatchError(sss.ErrInvalidShare))
+
+					inbox["8801"]["8802"] = share
+					_, err := participants["8801"].Finalize(packages, inbox["8801"])
+					Expect(err).ToNot(BeNil())
+				})
+
+				It("should refuse to finalize with a missing share", func() {
+					delete(inbox["8801"], "8803")
+					_, err := participants["8801"].Finalize(packages, inbox["8801"])
+					Expect(err).ToNot(BeNil())
+				})
+
+				It("should track the packages and shares still to arrive", func() {
+					Expect(participants["8801"].Awaiting(packages, inbox["8801"]).Done()).To(BeTrue())
+
+					delete(packages, "8802")
+					delete(inbox["8801"], "8803")
+					deliveries := participants["8801"].Awaiting(packages, inbox["8801"])
+					Expect(deliveries.Round1).To(Equal([]string{"8802"}))
+					Expect(deliveries.Round2).To(Equal([]string{"8803"}))
+
+					Expect(participants["8801"].Disqualify([]string{"8803"})).To(Succeed())
+					Expect(participants["8801"].Awaiting(packages, inbox["8801"]).Round2).To(BeEmpty())
+				})
+			})
+
+			Context("While refreshing shares", func() {
+				var keys map[string]*dkg.KeyPackage
+
+				BeforeEach(func() {
+					participants := make(map[string]*dkg.Participant)
+					for id := range parties {
+						participant, err := dkg.NewParticipant(1, cs, id, parties, 2)
+						Expect(err).To(BeNil())
+						participants[id] = participant
+					}
+					keys = run(participants)
+				})
+
+				refresh := func() map[string]*dkg.KeyPackage {
+					participants := make(map[string]*dkg.Participant)
+					for id, key := range keys {
+						participant, err := dkg.NewRefreshParticipant(2, key, parties)
+						Expect(err).To(BeNil())
+						participants[id] = participant
+					}
+					return run(participants)
+				}
+
+				It("should keep the group key and make old shares useless", func() {
+					refreshed := refresh()
+
+					for id, key := range refreshed {
+						Expect(key.GroupPublicKey.Equal(keys[id].GroupPublicKey)).To(BeTrue())
+						Expect(key.SigningShare.Equal(keys[id].SigningShare)).To(BeFalse())
+					}
+
+					secret, err := sss.Interpolate([]sss.Share{
+						{ID: refreshed["8801"].Identifier, Value: refreshed["8801"].SigningShare},
+						{ID: refreshed["8803"].Identifier, Value: refreshed["8803"].SigningShare},
+					})
+					Expect(err).To(BeNil())
+					Expect(group.ScalarBaseMult(secret).Equal(keys["8801"].GroupPublicKey)).To(BeTrue())
+
+					mixed, err := sss.Interpolate([]sss.Share{
+						{ID: refreshed["8801"].Identifier, Value: refreshed["8801"].SigningShare},
+						{ID: keys["8803"].Identifier, Value: keys["8803"].SigningShare},
+					})
+					Expect(err).To(BeNil())
+					Expect(mixed.Equal(secret)).To(BeFalse())
+				})
+
+				It("should reject a refresh package that moves the group key", func() {
+					participant, err := dkg.NewRefreshParticipant(2, keys["8801"], parties)
+					Expect(err).To(BeNil())
+
+					cheater, err := dkg.NewParticipant(2, cs, "8802", parties, 2)
+					Expect(err).To(BeNil())
+
+					pkg := dkg.Round1Package{Epoch: 2, Sender: "8802", Commitments: cheater.Polynomial.Commit()}
+					Expect(participant.VerifyRound1Package(pkg)).ToNot(Succeed())
+				})
+
+				It("should refuse to refresh for a different party set", func() {
+					_, err := dkg.NewRefreshParticipant(2, keys["8801"], sigagrpc.Parties{"8801": parties["8801"], "8802": parties["8802"]})
+					Expect(err).ToNot(BeNil())
+
+					_, err = dkg.NewRefreshParticipant(1, keys["8801"], parties)
+					Expect(err).ToNot(BeNil())
+				})
+			})
+
+			Context("While resharing to a new committee", func() {
+				var (
+					keys      map[string]*dkg.KeyPackage
+					resharing *dkg.Resharing
+				)
+
+				committee := sigagrpc.Parties{
+					"8802": "127.0.0.1:8802/",
+					"8803": "127.0.0.1:8803/",
+					"8804": "127.0.0.1:8804/",
+					"8805": "127.0.0.1:8805/",
+				}
+
+				BeforeEach(func() {
+					participants := make(map[string]*dkg.Participant)
+					for id := range parties {
+						participant, err := dkg.NewParticipant(1, cs, id, parties, 2)
+						Expect(err).To(BeNil())
+						participants[id] = participant
+					}
+					keys = run(participants)
+
+					resharing = &dkg.Resharing{
+						PreviousParties:    parties,
+						PreviousThreshold:  2,
+						Dealers:            []string{"8802", "8803"},
+						GroupPublicKey:     keys["8801"].GroupPublicKey,
+						VerificationShares: keys["8801"].VerificationShares,
+					}
+				})
+
+				It("should hand the group key to the new committee", func() {
+					participants := make(map[string]*dkg.Participant)
+					for id := range committee {
+						participant, err := dkg.NewResharingParticipant(2, cs, id, committee, 3, resharing, keys[id])
+						Expect(err).To(BeNil())
+						participants[id] = participant
+					}
+					Expect(participants["8804"].Dealer()).To(BeFalse())
+
+					reshared := run(participants)
+					for _, key := range reshared {
+						Expect(key.GroupPublicKey.Equal(keys["8801"].GroupPublicKey)).To(BeTrue())
+						Expect(key.Threshold).To(Equal(uint(3)))
+					}
+
+					secret, err := sss.Interpolate([]sss.Share{
+						{ID: reshared["8803"].Identifier, Value: reshared["8803"].SigningShare},
+						{ID: reshared["8804"].Identifier, Value: reshared["8804"].SigningShare},
+						{ID: reshared["8805"].Identifier, Value: reshared["8805"].SigningShare},
+					})
+					Expect(err).To(BeNil())
+					Expect(group.ScalarBaseMult(secret).Equal(keys["8801"].GroupPublicKey)).To(BeTrue())
+				})
+
+				It("should reject a dealer that does not re-share its share", func() {
+					participant, err := dkg.NewResharingParticipant(2, cs, "8804", committee, 3, resharing, nil)
+					Expect(err).To(BeNil())
+
+					cheater, err := dkg.NewParticipant(2, cs, "8802", committee, 3)
+					Expect(err).To(BeNil())
+
+					pkg := dkg.Round1Package{Epoch: 2, Sender: "8802", Commitments: cheater.Polynomial.Commit()}
+					Expect(participant.VerifyRound1Package(pkg)).ToNot(Succeed())
+
+					pkg = dkg.Round1Package{Epoch: 2, Sender: "8805", Commitments: cheater.Polynomial.Commit()}
+					Expect(participant.VerifyRound1Package(pkg)).ToNot(Succeed())
+				})
+
+				It("should refuse too few dealers or a dealer without a share", func() {
+					short := *resharing
+					short.Dealers = []string{"8802"}
+					_, err := dkg.NewResharingParticipant(2, cs, "8804", committee, 3, &short, nil)
+					Expect(err).ToNot(BeNil())
+
+					_, err = dkg.NewResharingParticipant(2, cs, "8802", committee, 3, resharing, nil)
+					Expect(err).ToNot(BeNil())
+
+					_, err = dkg.NewResharingParticipant(2, cs, "8802", committee, 3, resharing, keys["8803"])
+					Expect(err).ToNot(BeNil())
+				})
+			})
+		})
+	}
+})
+
+// run takes every participant through both rounds and returns the key
+// packages they derive. Only dealers send round 1 packages.
+func run(participants map[string]*dkg.Participant) map[string]*dkg.KeyPackage {
+	packages := make(map[string]dkg.Round1Package)
+	for id, participant := range participants {
+		if !participant.Dealer() {
+			continue
+		}
+
+		pkg, err := participant.DkGRound1()
+		Expect(err).To(BeNil())
+		packages[id] = pkg
+	}
+
+	inbox := make(map[string]map[string]dkg.Round2Share)
+	for id := range participants {
+		inbox[id] = make(map[string]dkg.Round2Share)
+	}
+
+	for _, participant := range participants {
+		shares, err := participant.DkGRound2(packages)
+		Expect(err).To(BeNil())
+		for _, share := range shares {
+			inbox[share.Recipient][share.Sender] = share
+		}
+	}
+
+	keys := make(map[string]*dkg.KeyPackage)
+	for id, participant := range participants {
+		key, err := participant.Finalize(packages, inbox[id])
+		Expect(err).To(BeNil())
+		keys[id] = key
+	}
+	return keys
+}
@@ -0,0 +1,74 @@
+package dkg
+
+import (
+	"crypto/sha512"
+	"fmt"
+	sigagrpc "frost/internal/sigag/rpc"
+	"frost/pkg/group"
+	"math/big"
+)
+
+// identifierDST separates the derivation of identifiers from every other use
+// of the hash.
+const identifierDST = "frost-golang/identifier/v1/"
+
+// Identifier derives a party's scalar identifier from its address: SHA-512
+// over a domain separator naming the group and the address, reduced modulo
+// the group order. The 512 bit digest makes the result uniform for the
+// groups in use, and the identifier depends on nothing but the address, so
+// it stays the same across epochs and party sets.
+func Identifier(g group.Group, id string) *group.Scalar {
+	h := sha512.New()
+	h.Write([]byte(identifierDST + g.Name()))
+	h.Write([]byte{0})
+	h.Write([]byte(id))
+	return group.NewScalar(g, new(big.Int).SetBytes(h.Sum(nil)))
+}
+
+// Identifiers maps every party to its identifier, making sure they are
+// non-zero and distinct. Either failing is as unlikely as a hash collision,
+// but would silently break every Lagrange coefficient.
+func Identifiers(g group.Group, parties sigagrpc.Parties) (map[string]*group.Scalar, error) {
+	identifiers := make(map[string]*group.Scalar, len(parties))
+	owners := make(map[string]string, len(parties))
+	for id := range parties {
+		identifier := Identifier(g, id)
+		if identifier.IsZero() {
+			return nil, fmt.Errorf("party %s has a zero identifier", id)
+		}
+
+		if other, ok := owners[identifier.String()]; ok {
+			return nil, fmt.Errorf("parties %s and %s share an identifier", other, id)
+		}
+
+		owners[identifier.String()] = id
+		identifiers[id] = identifier
+	}
+	return identifiers, nil
+}
+
+// CheckIdentifiers makes sure `identifiers`, as handed out by sigag, name
+// exactly the parties and match the identifiers derived here.
+func CheckIdentifiers(g group.Group, parties sigagrpc.Parties, identifiers map[string]*group.Scalar) error {
+	expected, err := Identifiers(g, parties)
+	if err != nil {
+		return err
+	}
+
+	if len(identifiers) != len(expected) {
+		return fmt.Errorf("%d identifiers for %d parties", len(identifiers), len(expected))
+	}
+
+	for id, identifier := range expected {
+		other, ok := identifiers[id]
+		if !ok {
+			return fmt.Errorf("no identifier for %s", id)
+		}
+
+		if other == nil || other.Group() != g || !other.Equal(identifier) {
+			return fmt.Errorf("identifier of %s does not match %s", id, identifier)
+		}
+	}
+
+	return nil
+}
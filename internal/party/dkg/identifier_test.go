@@ -0,0 +1,67 @@
+package dkg_test
+
+import (
+	"frost/internal/party/dkg"
+	sigagrpc "frost/internal/sigag/rpc"
+	"frost/pkg/frost"
+	"frost/pkg/group"
+	"math/big"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Identifier", func() {
+	for _, cs := range []frost.Ciphersuite{frost.Secp256k1SHA256, frost.Ed25519SHA512, frost.Ristretto255SHA512} {
+		cs := cs
+
+		Context("Over "+cs.ID(), func() {
+			g := cs.Group()
+
+			It("should derive the identifier from the address alone", func() {
+				identifiers, err := dkg.Identifiers(g, parties)
+				Expect(err).To(BeNil())
+
+				fewer, err := dkg.Identifiers(g, sigagrpc.Parties{"8802": "127.0.0.1:8802/"})
+				Expect(err).To(BeNil())
+
+				Expect(identifiers).To(HaveLen(len(parties)))
+				Expect(fewer["8802"].Equal(identifiers["8802"])).To(BeTrue())
+				Expect(identifiers["8802"].Equal(dkg.Identifier(g, "8802"))).To(BeTrue())
+				Expect(identifiers["8801"].Equal(identifiers["8802"])).To(BeFalse())
+				Expect(identifiers["8801"].Equal(group.NewScalar(g, big.NewInt(1)))).To(BeFalse())
+			})
+
+			It("should accept the identifiers it derives", func() {
+				identifiers, err := dkg.Identifiers(g, parties)
+				Expect(err).To(BeNil())
+				Expect(dkg.CheckIdentifiers(g, parties, identifiers)).To(Succeed())
+			})
+
+			It("should refuse identifiers that differ from its own", func() {
+				identifiers, err := dkg.Identifiers(g, parties)
+				Expect(err).To(BeNil())
+
+				swapped := map[string]*group.Scalar{
+					"8801": identifiers["8802"],
+					"8802": identifiers["8801"],
+					"8803": identifiers["8803"],
+				}
+				Expect(dkg.CheckIdentifiers(g, parties, swapped)).ToNot(Succeed())
+
+				positional := map[string]*group.Scalar{
+					"8801": group.NewScalar(g, big.NewInt(1)),
+					"8802": group.NewScalar(g, big.NewInt(2)),
+					"8803": group.NewScalar(g, big.NewInt(3)),
+				}
+				Expect(dkg.CheckIdentifiers(g, parties, positional)).ToNot(Succeed())
+
+				delete(identifiers, "8803")
+				Expect(dkg.CheckIdentifiers(g, parties, identifiers)).ToNot(Succeed())
+
+				identifiers["9999"] = dkg.Identifier(g, "9999")
+				Expect(dkg.CheckIdentifiers(g, parties, identifiers)).ToNot(Succeed())
+			})
+		})
+	}
+})
@@ -0,0 +1,291 @@
+package dkg
+
+import (
+	"fmt"
+	sigagrpc "frost/internal/sigag/rpc"
+	sss "frost/pkg/SSS"
+	"frost/pkg/frost"
+	"frost/pkg/group"
+)
+
+// Repair rebuilds the share a party lost for its existing identifier with the
+// help of threshold other holders, following the repairable threshold scheme
+// of Laing and Stinson. Every helper i splits λ_i(x_lost)·s_i into one random
+// part per helper, every helper sums the parts it receives into σ_j and the
+// lost party adds up the σ_j. No helper sees more than random parts of the
+// other helpers' contributions, and the lost party only sees their sums.
+type Repair struct {
+	Epoch   uint             `json:"epoch"`
+	Lost    string           `json:"lost"`
+	Helpers []string         `json:"helpers"`
+	Parties sigagrpc.Parties `json:"parties"`
+}
+
+// RepairDelta is the part of a helper's contribution λ_i(x_lost)·s_i that it
+// sends privately to another helper.
+type RepairDelta struct {
+	Epoch     uint          `json:"epoch"`
+	Lost      string        `json:"lost"`
+	Sender    string        `json:"sender"`
+	Recipient string        `json:"recipient"`
+	Value     *group.Scalar `json:"value"`
+}
+
+// RepairSigma is a helper's sum of the deltas it received, sent privately to
+// the lost party.
+type RepairSigma struct {
+	Epoch  uint          `json:"epoch"`
+	Lost   string        `json:"lost"`
+	Sender string        `json:"sender"`
+	Value  *group.Scalar `json:"value"`
+}
+
+// Recovery is the lost party's side of a repair. The public key material of
+// the epoch, as published by sigag, is what the rebuilt share must match.
+type Recovery struct {
+	Repair
+	Ciphersuite        string                    `json:"ciphersuite"`
+	Threshold          uint                      `json:"threshold"`
+	GroupPublicKey     *group.Element            `json:"group_public_key"`
+	VerificationShares map[string]*group.Element `json:"verification_shares"`
+}
+
+// Check makes sure the lost party and at least `threshold` distinct helpers
+// all hold an identifier in the epoch.
+func (r Repair) Check(threshold uint) error {
+	if _, ok := r.Parties[r.Lost]; !ok {
+		return fmt.Errorf("party %s is not part of epoch %d", r.Lost, r.Epoch)
+	}
+
+	if uint(len(r.Helpers)) < threshold {
+		return fmt.Errorf("repair needs %d helpers, got %d", threshold, len(r.Helpers))
+	}
+
+	seen := make(map[string]bool, len(r.Helpers))
+	for _, helper := range r.Helpers {
+		if helper == r.Lost {
+			return fmt.Errorf("party %s cannot help repair its own share", helper)
+		}
+
+		if seen[helper] {
+			return fmt.Errorf("duplicate helper %s", helper)
+		}
+		seen[helper] = true
+
+		if _, ok := r.Parties[helper]; !ok {
+			return fmt.Errorf("helper %s is not part of epoch %d", helper, r.Epoch)
+		}
+	}
+
+	return nil
+}
+
+// isHelper reports whether id is one of the repair's helpers.
+func (r Repair) isHelper(id string) bool {
+	for _, helper := range r.Helpers {
+		if helper == id {
+			return true
+		}
+	}
+	return false
+}
+
+// RepairDeltas splits the helper's contribution λ_i(x_lost)·s_i, with λ_i the
+// Lagrange coefficient over the helpers evaluated at the lost identifier, into
+// one random delta per helper, its own included.
+func RepairDeltas(key *KeyPackage, repair Repair) ([]RepairDelta, error) {
+	if err := checkHelper(key, repair); err != nil {
+		return nil, err
+	}
+
+	g := key.Identifier.Group()
+	identifiers, err := Identifiers(g, repair.Parties)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]*group.Scalar, 0, len(repair.Helpers))
+	for _, helper := range repair.Helpers {
+		ids = append(ids, identifiers[helper])
+	}
+
+	lambda, err := sss.LagrangeCoefficientAt(key.Identifier, ids, identifiers[repair.Lost])
+	if err != nil {
+		return nil, err
+	}
+
+	remainder := lambda.Mul(key.SigningShare)
+	deltas := make([]RepairDelta, 0, len(repair.Helpers))
+	for i, helper := range repair.Helpers {
+		value := remainder
+		if i < len(repair.Helpers)-1 {
+			if value, err = group.RandomScalar(g); err != nil {
+				return nil, err
+			}
+			remainder = remainder.Sub(value)
+		}
+
+		deltas = append(deltas, RepairDelta{
+			Epoch:     repair.Epoch,
+			Lost:      repair.Lost,
+			Sender:    key.ID,
+			Recipient: helper,
+			Value:     value,
+		})
+	}
+
+	return deltas, nil
+}
+
+// VerifyRepairDelta checks that a delta belongs to the repair and is
+// addressed to this helper. Deltas are random, so there is nothing else to
+// check until the lost party verifies the rebuilt share.
+func VerifyRepairDelta(key *KeyPackage, repair Repair, delta RepairDelta) error {
+	if delta.Epoch != repair.Epoch || delta.Lost != repair.Lost {
+		return fmt.Errorf("repair delta for %s in epoch %d, expected %s in epoch %d", delta.Lost, delta.Epoch, repair.Lost, repair.Epoch)
+	}
+
+	if delta.Recipient != key.ID {
+		return fmt.Errorf("repair delta addressed to %s", delta.Recipient)
+	}
+
+	if !repair.isHelper(delta.Sender) {
+		return fmt.Errorf("repair delta from %s, which does not help", delta.Sender)
+	}
+
+	if delta.Value == nil || delta.Value.Group() != key.Identifier.Group() {
+		return fmt.Errorf("repair delta from %s has no value", delta.Sender)
+	}
+
+	return nil
+}
+
+// RepairSigmaOf sums the deltas the helper received from every helper into
+// the σ it hands to the lost party.
+func RepairSigmaOf(key *KeyPackage, repair Repair, deltas map[string]RepairDelta) (RepairSigma, error) {
+	if err := checkHelper(key, repair); err != nil {
+		return RepairSigma{}, err
+	}
+
+	sigma := group.ScalarFromInt(key.Identifier.Group(), 0)
+	for _, helper := range repair.Helpers {
+		delta, ok := deltas[helper]
+		if !ok {
+			return RepairSigma{}, fmt.Errorf("missing repair delta from %s", helper)
+		}
+
+		if err := VerifyRepairDelta(key, repair, delta); err != nil {
+			return RepairSigma{}, err
+		}
+
+		sigma = sigma.Add(delta.Value)
+	}
+
+	if len(deltas) != len(repair.Helpers) {
+		return RepairSigma{}, fmt.Errorf("expected %d repair deltas, got %d", len(repair.Helpers), len(deltas))
+	}
+
+	return RepairSigma{
+		Epoch:  repair.Epoch,
+		Lost:   repair.Lost,
+		Sender: key.ID,
+		Value:  sigma,
+	}, nil
+}
+
+// checkHelper makes sure the key package lets its holder help in the repair.
+func checkHelper(key *KeyPackage, repair Repair) error {
+	if key.Epoch != repair.Epoch {
+		return fmt.Errorf("key of epoch %d cannot repair epoch %d", key.Epoch, repair.Epoch)
+	}
+
+	if !repair.isHelper(key.ID) {
+		return fmt.Errorf("party %s does not help repair %s", key.ID, repair.Lost)
+	}
+
+	if err := repair.Check(key.Threshold); err != nil {
+		return err
+	}
+
+	if !Identifier(key.Identifier.Group(), key.ID).Equal(key.Identifier) {
+		return fmt.Errorf("parties of the repair do not match the identifier of %s", key.ID)
+	}
+
+	return nil
+}
+
+// Check makes sure the recovery describes a valid repair of a published key.
+func (r *Recovery) Check() error {
+	cs, err := frost.CiphersuiteByID(r.Ciphersuite)
+	if err != nil {
+		return err
+	}
+
+	if r.GroupPublicKey == nil || r.GroupPublicKey.Group() != cs.Group() {
+		return fmt.Errorf("recovery without a %s group key", cs.Group().Name())
+	}
+
+	if share := r.VerificationShares[r.Lost]; share == nil || share.Group() != cs.Group() {
+		return fmt.Errorf("recovery without the verification share of %s", r.Lost)
+	}
+
+	return r.Repair.Check(r.Threshold)
+}
+
+// VerifyRepairSigma checks that a σ belongs to the recovery and comes from
+// one of its helpers.
+func (r *Recovery) VerifyRepairSigma(sigma RepairSigma) error {
+	if sigma.Epoch != r.Epoch || sigma.Lost != r.Lost {
+		return fmt.Errorf("repair sigma for %s in epoch %d, expected %s in epoch %d", sigma.Lost, sigma.Epoch, r.Lost, r.Epoch)
+	}
+
+	if !r.isHelper(sigma.Sender) {
+		return fmt.Errorf("repair sigma from %s, which does not help", sigma.Sender)
+	}
+
+	if sigma.Value == nil || sigma.Value.Group() != r.GroupPublicKey.Group() {
+		return fmt.Errorf("repair sigma from %s has no value", sigma.Sender)
+	}
+
+	return nil
+}
+
+// Finalize adds up the σ of every helper into the lost share and checks it
+// against the published verification share before handing out a key package.
+func (r *Recovery) Finalize(sigmas map[string]RepairSigma) (*KeyPackage, error) {
+	if err := r.Check(); err != nil {
+		return nil, err
+	}
+
+	g := r.GroupPublicKey.Group()
+	signingShare := group.ScalarFromInt(g, 0)
+	for _, helper := range r.Helpers {
+		sigma, ok := sigmas[helper]
+		if !ok {
+			return nil, fmt.Errorf("missing repair sigma from %s", helper)
+		}
+
+		if err := r.VerifyRepairSigma(sigma); err != nil {
+			return nil, err
+		}
+
+		signingShare = signingShare.Add(sigma.Value)
+	}
+
+	verificationShare := group.ScalarBaseMult(signingShare)
+	if !verificationShare.Equal(r.VerificationShares[r.Lost]) {
+		return nil, fmt.Errorf("repaired share does not match the verification share of %s", r.Lost)
+	}
+
+	return &KeyPackage{
+		Epoch:              r.Epoch,
+		Ciphersuite:        r.Ciphersuite,
+		ID:                 r.Lost,
+		Identifier:         Identifier(g, r.Lost),
+		Threshold:          r.Threshold,
+		SigningShare:       signingShare,
+		VerificationShare:  verificationShare,
+		GroupPublicKey:     r.GroupPublicKey,
+		VerificationShares: r.VerificationShares,
+	}, nil
+}
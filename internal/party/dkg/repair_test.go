@@ -0,0 +1,117 @@
+package dkg_test
+
+import (
+	"frost/internal/party/dkg"
+	sigagrpc "frost/internal/sigag/rpc"
+	"frost/pkg/frost"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Repair", func() {
+	committee := sigagrpc.Parties{
+		"8801": "127.0.0.1:8801/",
+		"8802": "127.0.0.1:8802/",
+		"8803": "127.0.0.1:8803/",
+		"8804": "127.0.0.1:8804/",
+	}
+
+	for _, cs := range []frost.Ciphersuite{frost.Secp256k1SHA256, frost.Ed25519SHA512, frost.Ristretto255SHA512} {
+		cs := cs
+
+		Context("Over "+cs.ID(), func() {
+			var (
+				keys     map[string]*dkg.KeyPackage
+				repair   dkg.Repair
+				recovery *dkg.Recovery
+			)
+
+			BeforeEach(func() {
+				participants := make(map[string]*dkg.Participant)
+				for id := range committee {
+					participant, err := dkg.NewParticipant(1, cs, id, committee, 3)
+					Expect(err).To(BeNil())
+					participants[id] = participant
+				}
+				keys = run(participants)
+
+				repair = dkg.Repair{Epoch: 1, Lost: "8802", Helpers: []string{"8801", "8803", "8804"}, Parties: committee}
+				recovery = &dkg.Recovery{
+					Repair:             repair,
+					Ciphersuite:        cs.ID(),
+					Threshold:          3,
+					GroupPublicKey:     keys["8801"].GroupPublicKey,
+					VerificationShares: keys["8801"].VerificationShares,
+				}
+			})
+
+			// help runs both helper steps and returns the σ every helper sends
+			help := func() map[string]dkg.RepairSigma {
+				inbox := make(map[string]map[string]dkg.RepairDelta)
+				for _, helper := range repair.Helpers {
+					inbox[helper] = make(map[string]dkg.RepairDelta)
+				}
+
+				for _, helper := range repair.Helpers {
+					deltas, err := dkg.RepairDeltas(keys[helper], repair)
+					Expect(err).To(BeNil())
+					Expect(deltas).To(HaveLen(len(repair.Helpers)))
+					for _, delta := range deltas {
+						inbox[delta.Recipient][delta.Sender] = delta
+					}
+				}
+
+				sigmas := make(map[string]dkg.RepairSigma)
+				for _, helper := range repair.Helpers {
+					sigma, err := dkg.RepairSigmaOf(keys[helper], repair, inbox[helper])
+					Expect(err).To(BeNil())
+					sigmas[helper] = sigma
+				}
+				return sigmas
+			}
+
+			It("should rebuild the lost share for its identifier", func() {
+				key, err := recovery.Finalize(help())
+				Expect(err).To(BeNil())
+				Expect(key.SigningShare.Equal(keys["8802"].SigningShare)).To(BeTrue())
+				Expect(key.Identifier.Equal(keys["8802"].Identifier)).To(BeTrue())
+				Expect(key.GroupPublicKey.Equal(keys["8802"].GroupPublicKey)).To(BeTrue())
+			})
+
+			It("should not hand any helper its own contribution in the clear", func() {
+				deltas, err := dkg.RepairDeltas(keys["8801"], repair)
+				Expect(err).To(BeNil())
+
+				again, err := dkg.RepairDeltas(keys["8801"], repair)
+				Expect(err).To(BeNil())
+
+				for i := range deltas {
+					Expect(deltas[i].Value.Equal(again[i].Value)).To(BeFalse())
+				}
+			})
+
+			It("should reject a σ that does not rebuild the share", func() {
+				sigmas := help()
+				sigma := sigmas["8803"]
+				sigma.Value = sigma.Value.Add(keys["8801"].SigningShare)
+				sigmas["8803"] = sigma
+
+				_, err := recovery.Finalize(sigmas)
+				Expect(err).ToNot(BeNil())
+			})
+
+			It("should refuse too few helpers or the lost party as a helper", func() {
+				short := repair
+				short.Helpers = []string{"8801", "8803"}
+				_, err := dkg.RepairDeltas(keys["8801"], short)
+				Expect(err).ToNot(BeNil())
+
+				self := repair
+				self.Helpers = []string{"8801", "8802", "8803"}
+				_, err = dkg.RepairDeltas(keys["8801"], self)
+				Expect(err).ToNot(BeNil())
+			})
+		})
+	}
+})
@@ -0,0 +1,107 @@
+package dkg
+
+import (
+	"fmt"
+	"frost/internal/party/identity"
+	"frost/pkg/group"
+)
+
+// EncryptedShare is a round 2 share sealed from the sender's identity key to
+// the recipient's. The epoch, sender and recipient travel in the clear and
+// are bound to the ciphertext as associated data.
+type EncryptedShare struct {
+	Epoch     uint   `json:"epoch"`
+	Sender    string `json:"sender"`
+	Recipient string `json:"recipient"`
+
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// SetIdentityKeys records the public identity key of every party, checking
+// that the participant's own entry is `own`.
+func (p *Participant) SetIdentityKeys(keys map[string]string, own string) error {
+	for id := range p.Parties {
+		key, ok := keys[id]
+		if !ok {
+			return fmt.Errorf("no identity key for %s", id)
+		}
+
+		if _, err := identity.ParsePublicKey(key); err != nil {
+			return fmt.Errorf("identity key of %s: %w", id, err)
+		}
+	}
+
+	if keys[p.ID] != own {
+		return fmt.Errorf("identity key of %s does not match its own", p.ID)
+	}
+
+	p.IdentityKeys = keys
+	return nil
+}
+
+// SealShare encrypts a share the participant deals to its recipient.
+func (p *Participant) SealShare(key *identity.Key, share Round2Share) (EncryptedShare, error) {
+	if share.Sender != p.ID {
+		return EncryptedShare{}, fmt.Errorf("cannot seal a share of %s", share.Sender)
+	}
+
+	recipient, ok := p.IdentityKeys[share.Recipient]
+	if !ok {
+		return EncryptedShare{}, fmt.Errorf("no identity key for %s", share.Recipient)
+	}
+
+	sealed := EncryptedShare{
+		Epoch:     share.Epoch,
+		Sender:    share.Sender,
+		Recipient: share.Recipient,
+	}
+
+	var err error
+	sealed.Nonce, sealed.Ciphertext, err = key.Seal(recipient, share.Value.Bytes(), sealed.aad())
+	if err != nil {
+		return EncryptedShare{}, err
+	}
+
+	return sealed, nil
+}
+
+// OpenShare decrypts a share sealed to the participant. A share that does not
+// decrypt under the sender's identity key, or was sealed for another epoch or
+// recipient, is rejected.
+func (p *Participant) OpenShare(key *identity.Key, sealed EncryptedShare) (Round2Share, error) {
+	if sealed.Epoch != p.Epoch {
+		return Round2Share{}, fmt.Errorf("round 2 share for epoch %d, expected %d", sealed.Epoch, p.Epoch)
+	}
+
+	if sealed.Recipient != p.ID {
+		return Round2Share{}, fmt.Errorf("round 2 share addressed to %s", sealed.Recipient)
+	}
+
+	sender, ok := p.IdentityKeys[sealed.Sender]
+	if !ok {
+		return Round2Share{}, fmt.Errorf("no identity key for %s", sealed.Sender)
+	}
+
+	plaintext, err := key.Open(sender, sealed.Nonce, sealed.Ciphertext, sealed.aad())
+	if err != nil {
+		return Round2Share{}, fmt.Errorf("round 2 share from %s: %w", sealed.Sender, err)
+	}
+
+	value, err := group.DeserializeScalar(p.Ciphersuite.Group(), plaintext)
+	if err != nil {
+		return Round2Share{}, fmt.Errorf("round 2 share from %s: %w", sealed.Sender, err)
+	}
+
+	return Round2Share{
+		Epoch:     sealed.Epoch,
+		Sender:    sealed.Sender,
+		Recipient: sealed.Recipient,
+		Value:     value,
+	}, nil
+}
+
+// aad binds the epoch and both parties to the ciphertext.
+func (e EncryptedShare) aad() []byte {
+	return []byte(fmt.Sprintf("frost-golang/dkg/epoch/%d/share/%s/%s", e.Epoch, e.Sender, e.Recipient))
+}
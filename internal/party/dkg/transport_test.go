@@ -0,0 +1,95 @@
+package dkg_test
+
+import (
+	"frost/internal/party/dkg"
+	"frost/internal/party/identity"
+	"frost/pkg/frost"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Transport", func() {
+	for _, cs := range []frost.Ciphersuite{frost.Secp256k1SHA256, frost.Ed25519SHA512, frost.Ristretto255SHA512} {
+		cs := cs
+
+		Context("Over "+cs.ID(), func() {
+			var (
+				participants map[string]*dkg.Participant
+				keys         map[string]*identity.Key
+				share        dkg.Round2Share
+			)
+
+			BeforeEach(func() {
+				participants = make(map[string]*dkg.Participant)
+				keys = make(map[string]*identity.Key)
+				public := make(map[string]string)
+
+				for id := range parties {
+					key, err := identity.Generate()
+					Expect(err).To(BeNil())
+					keys[id] = key
+					public[id] = key.PublicKey()
+				}
+
+				packages := make(map[string]dkg.Round1Package)
+				for id := range parties {
+					participant, err := dkg.NewParticipant(1, cs, id, parties, 2)
+					Expect(err).To(BeNil())
+					Expect(participant.SetIdentityKeys(public, keys[id].PublicKey())).To(Succeed())
+					participants[id] = participant
+
+					packages[id], err = participant.DkGRound1()
+					Expect(err).To(BeNil())
+				}
+
+				shares, err := participants["8801"].DkGRound2(packages)
+				Expect(err).To(BeNil())
+				for _, s := range shares {
+					if s.Recipient == "8802" {
+						share = s
+					}
+				}
+			})
+
+			It("should only open a share for its recipient", func() {
+				sealed, err := participants["8801"].SealShare(keys["8801"], share)
+				Expect(err).To(BeNil())
+
+				opened, err := participants["8802"].OpenShare(keys["8802"], sealed)
+				Expect(err).To(BeNil())
+				Expect(opened.Value.Equal(share.Value)).To(BeTrue())
+
+				_, err = participants["8803"].OpenShare(keys["8803"], sealed)
+				Expect(err).ToNot(BeNil())
+			})
+
+			It("should reject shares whose associated data or ciphertext changed", func() {
+				sealed, err := participants["8801"].SealShare(keys["8801"], share)
+				Expect(err).To(BeNil())
+
+				forged := sealed
+				forged.Sender = "8803"
+				_, err = participants["8802"].OpenShare(keys["8802"], forged)
+				Expect(err).To(MatchError(identity.ErrUnauthenticated))
+
+				forged = sealed
+				forged.Ciphertext = append([]byte(nil), sealed.Ciphertext...)
+				forged.Ciphertext[0] ^= 1
+				_, err = participants["8802"].OpenShare(keys["8802"], forged)
+				Expect(err).To(MatchError(identity.ErrUnauthenticated))
+			})
+
+			It("should refuse identity keys that miss a party or do not match its own", func() {
+				other, err := identity.Generate()
+				Expect(err).To(BeNil())
+
+				keys := map[string]string{"8801": other.PublicKey(), "8802": other.PublicKey()}
+				Expect(participants["8801"].SetIdentityKeys(keys, other.PublicKey())).ToNot(Succeed())
+
+				keys["8803"] = other.PublicKey()
+				Expect(participants["8801"].SetIdentityKeys(keys, "00")).ToNot(Succeed())
+			})
+		})
+	}
+})
@@ -0,0 +1,220 @@
+// long-term party identity keys, the authenticated encryption of messages
+// between two identities and the signatures parties put on their messages
+package identity
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+var (
+	ErrUnauthenticated = errors.New("message does not decrypt or authenticate")
+	ErrBadSignature    = errors.New("signature does not verify")
+)
+
+// label separates keys derived here from any other use of the shared secret.
+const label = "frost-golang/identity/v1"
+
+// Key is a party's long-term identity: an X25519 key messages are sealed to
+// and an Ed25519 key it signs its messages with. Both public halves are
+// registered with sigag, which hands them to the other parties.
+type Key struct {
+	private *ecdh.PrivateKey
+	signer  ed25519.PrivateKey
+}
+
+// Generate samples a new identity key.
+func Generate() (*Key, error) {
+	private, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	_, signer, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Key{private: private, signer: signer}, nil
+}
+
+// keyJSON is the encoding of a Key: the X25519 private key and the Ed25519
+// seed, hex encoded.
+type keyJSON struct {
+	Private string `json:"private"`
+	Seed    string `json:"seed"`
+}
+
+// MarshalJSON encodes the private halves of the key, so a party can keep its
+// identity across restarts.
+func (k *Key) MarshalJSON() ([]byte, error) {
+	return json.Marshal(keyJSON{
+		Private: hex.EncodeToString(k.private.Bytes()),
+		Seed:    hex.EncodeToString(k.signer.Seed()),
+	})
+}
+
+// UnmarshalJSON decodes a key encoded by MarshalJSON.
+func (k *Key) UnmarshalJSON(data []byte) error {
+	var encoded keyJSON
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(encoded.Private)
+	if err != nil {
+		return fmt.Errorf("identity key is not hex encoded: %w", err)
+	}
+	private, err := ecdh.X25519().NewPrivateKey(b)
+	if err != nil {
+		return fmt.Errorf("invalid identity key: %w", err)
+	}
+
+	seed, err := hex.DecodeString(encoded.Seed)
+	if err != nil {
+		return fmt.Errorf("signing key is not hex encoded: %w", err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return fmt.Errorf("invalid signing key seed of %d bytes", len(seed))
+	}
+
+	k.private = private
+	k.signer = ed25519.NewKeyFromSeed(seed)
+	return nil
+}
+
+// PublicKey returns the hex encoded public identity key.
+func (k *Key) PublicKey() string {
+	return hex.EncodeToString(k.private.PublicKey().Bytes())
+}
+
+// ParsePublicKey decodes a hex encoded public identity key.
+func ParsePublicKey(encoded string) (*ecdh.PublicKey, error) {
+	b, err := hex.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("identity key is not hex encoded: %w", err)
+	}
+
+	public, err := ecdh.X25519().NewPublicKey(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid identity key: %w", err)
+	}
+	return public, nil
+}
+
+// SigningKey returns the hex encoded public signing key.
+func (k *Key) SigningKey() string {
+	return hex.EncodeToString(k.signer.Public().(ed25519.PublicKey))
+}
+
+// ParseSigningKey decodes a hex encoded public signing key.
+func ParseSigningKey(encoded string) (ed25519.PublicKey, error) {
+	b, err := hex.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("signing key is not hex encoded: %w", err)
+	}
+
+	if len(b) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid signing key of %d bytes", len(b))
+	}
+	return ed25519.PublicKey(b), nil
+}
+
+// Sign signs message with the identity's signing key.
+func (k *Key) Sign(message []byte) []byte {
+	return ed25519.Sign(k.signer, message)
+}
+
+// Verify checks a signature of the identity registered with `signingKey`.
+func Verify(signingKey string, message, signature []byte) error {
+	public, err := ParseSigningKey(signingKey)
+	if err != nil {
+		return err
+	}
+
+	if !ed25519.Verify(public, message, signature) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+// Seal encrypts plaintext to the holder of the `peer` identity with
+// AES-256-GCM under a key derived from the static X25519 secret of both
+// identities. Only the two of them can derive the key, so a message that
+// opens was sealed by the peer. aad is authenticated but not encrypted.
+func (k *Key) Seal(peer string, plaintext, aad []byte) ([]byte, []byte, error) {
+	aead, err := k.aead(peer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, nil, err
+	}
+
+	return nonce, aead.Seal(nil, nonce, plaintext, aad), nil
+}
+
+// Open decrypts a message the `peer` identity sealed to this one.
+func (k *Key) Open(peer string, nonce, ciphertext, aad []byte) ([]byte, error) {
+	aead, err := k.aead(peer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nonce) != aead.NonceSize() {
+		return nil, ErrUnauthenticated
+	}
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, aad)
+	if err != nil {
+		return nil, ErrUnauthenticated
+	}
+	return plaintext, nil
+}
+
+// aead derives the AES-256-GCM cipher both identities share. The public keys
+// enter the key derivation in a fixed order, so both sides derive the same key.
+func (k *Key) aead(peer string) (cipher.AEAD, error) {
+	public, err := ParsePublicKey(peer)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := k.private.ECDH(public)
+	if err != nil {
+		return nil, err
+	}
+
+	own, other := k.private.PublicKey().Bytes(), public.Bytes()
+	if bytes.Compare(own, other) > 0 {
+		own, other = other, own
+	}
+
+	info := append([]byte(label), own...)
+	info = append(info, other...)
+
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, nil, info), key); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,93 @@
+package identity_test
+
+import (
+	"encoding/json"
+	"frost/internal/party/identity"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Identity", func() {
+	var alice, bob, eve *identity.Key
+
+	BeforeEach(func() {
+		var err error
+		alice, err = identity.Generate()
+		Expect(err).To(BeNil())
+		bob, err = identity.Generate()
+		Expect(err).To(BeNil())
+		eve, err = identity.Generate()
+		Expect(err).To(BeNil())
+	})
+
+	It("should open what the peer sealed to it", func() {
+		nonce, ciphertext, err := alice.Seal(bob.PublicKey(), []byte("share"), []byte("aad"))
+		Expect(err).To(BeNil())
+		Expect(ciphertext).ToNot(ContainSubstring("share"))
+
+		plaintext, err := bob.Open(alice.PublicKey(), nonce, ciphertext, []byte("aad"))
+		Expect(err).To(BeNil())
+		Expect(plaintext).To(Equal([]byte("share")))
+	})
+
+	It("should reject tampered ciphertexts and associated data", func() {
+		nonce, ciphertext, err := alice.Seal(bob.PublicKey(), []byte("share"), []byte("aad"))
+		Expect(err).To(BeNil())
+
+		_, err = bob.Open(alice.PublicKey(), nonce, ciphertext, []byte("other"))
+		Expect(err).To(MatchError(identity.ErrUnauthenticated))
+
+		ciphertext[0] ^= 1
+		_, err = bob.Open(alice.PublicKey(), nonce, ciphertext, []byte("aad"))
+		Expect(err).To(MatchError(identity.ErrUnauthenticated))
+	})
+
+	It("should reject messages from another identity or to another recipient", func() {
+		nonce, ciphertext, err := eve.Seal(bob.PublicKey(), []byte("share"), nil)
+		Expect(err).To(BeNil())
+		_, err = bob.Open(alice.PublicKey(), nonce, ciphertext, nil)
+		Expect(err).To(MatchError(identity.ErrUnauthenticated))
+
+		nonce, ciphertext, err = alice.Seal(bob.PublicKey(), []byte("share"), nil)
+		Expect(err).To(BeNil())
+		_, err = eve.Open(alice.PublicKey(), nonce, ciphertext, nil)
+		Expect(err).To(MatchError(identity.ErrUnauthenticated))
+	})
+
+	It("should verify signatures of the signing key only", func() {
+		signature := alice.Sign([]byte("message"))
+		Expect(identity.Verify(alice.SigningKey(), []byte("message"), signature)).To(Succeed())
+		Expect(identity.Verify(alice.SigningKey(), []byte("other"), signature)).To(MatchError(identity.ErrBadSignature))
+		Expect(identity.Verify(eve.SigningKey(), []byte("message"), signature)).To(MatchError(identity.ErrBadSignature))
+	})
+
+	It("should reject malformed identity keys", func() {
+		_, err := identity.ParsePublicKey("zz")
+		Expect(err).ToNot(BeNil())
+
+		_, err = identity.ParseSigningKey("00ff")
+		Expect(err).ToNot(BeNil())
+
+		_, _, err = alice.Seal("00ff", []byte("share"), nil)
+		Expect(err).ToNot(BeNil())
+	})
+
+	It("should open and sign the same after a json roundtrip", func() {
+		data, err := json.Marshal(alice)
+		Expect(err).To(BeNil())
+
+		restored := &identity.Key{}
+		Expect(json.Unmarshal(data, restored)).To(Succeed())
+		Expect(restored.PublicKey()).To(Equal(alice.PublicKey()))
+		Expect(restored.SigningKey()).To(Equal(alice.SigningKey()))
+
+		nonce, ciphertext, err := bob.Seal(alice.PublicKey(), []byte("share"), nil)
+		Expect(err).To(BeNil())
+		plaintext, err := restored.Open(bob.PublicKey(), nonce, ciphertext, nil)
+		Expect(err).To(BeNil())
+		Expect(plaintext).To(Equal([]byte("share")))
+
+		Expect(identity.Verify(alice.SigningKey(), []byte("message"), restored.Sign([]byte("message")))).To(Succeed())
+	})
+})
@@ -0,0 +1,208 @@
+// store-and-forward transport between parties that can only reach sigag
+package mailbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"frost/internal/party/dkg"
+	"frost/internal/party/identity"
+	"frost/internal/party/rpc"
+	sigagrpc "frost/internal/sigag/rpc"
+	client "frost/internal/sigag/sigagclient"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Mailbox sends a party's messages to other parties through sigag and fetches
+// the messages waiting for it. Every message is sealed to the recipient's
+// identity key and signed with the sender's, so sigag only forwards it.
+type Mailbox struct {
+	id       string
+	identity *identity.Key
+	sigag    client.SigAgClient
+	logger   *logrus.Logger
+
+	mu sync.Mutex
+	// after is the number of the last message up to which every message was
+	// handled, so sigag drops them.
+	after uint64
+	// handled holds the messages after `after` that were handled while an
+	// earlier one waits to be retried.
+	handled map[uint64]bool
+	// attempts counts the failed deliveries of the messages waiting.
+	attempts map[uint64]int
+}
+
+// MaxDeliveries is how many syncs a message the party refuses is delivered
+// on before it is dropped.
+const MaxDeliveries = 10
+
+func New(id string, identity *identity.Key, sigag client.SigAgClient, logger *logrus.Logger) *Mailbox {
+	return &Mailbox{
+		id:       id,
+		identity: identity,
+		sigag:    sigag,
+		logger:   logger,
+		handled:  make(map[uint64]bool),
+		attempts: make(map[uint64]int),
+	}
+}
+
+// Dial implements rpc.Transport. The url is not needed, messages are left
+// at sigag.
+func (m *Mailbox) Dial(id, _ string) rpc.Peer {
+	return peer{mailbox: m, id: id}
+}
+
+// Sync implements rpc.Transport. It fetches the inbox and delivers the
+// messages in the order they were posted. A message that does not open can
+// never be delivered and is logged and dropped. A message the party refuses
+// may have come before the step it belongs to, and is delivered again on the
+// next syncs until it is accepted or MaxDeliveries is reached.
+func (m *Mailbox) Sync(deliver rpc.Deliver) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	request := sigagrpc.MailboxFetchRequest{Party: m.id, After: m.after}
+	request.Signature = m.identity.Sign(request.SignedBytes())
+
+	messages, err := m.sigag.MailboxFetch(request)
+	if err != nil {
+		return err
+	}
+
+	if len(messages) == 0 {
+		return nil
+	}
+
+	keys, err := m.identityKeys(messages)
+	if err != nil {
+		return err
+	}
+
+	waiting := false
+	for _, message := range messages {
+		if !m.handled[message.Seq] && !m.handle(keys, message, deliver) {
+			waiting = true
+			continue
+		}
+
+		if waiting {
+			m.handled[message.Seq] = true
+			continue
+		}
+
+		m.after = message.Seq
+		delete(m.handled, message.Seq)
+	}
+
+	return nil
+}
+
+// handle opens and delivers a message, telling whether it is done with. It
+// is not when the party refused it fewer than MaxDeliveries times.
+func (m *Mailbox) handle(keys sigagrpc.IdentityKeys, message sigagrpc.MailboxMessage, deliver rpc.Deliver) bool {
+	params, err := m.open(keys, message)
+	if err != nil {
+		m.logger.Errorf("dropped %s from %s: %v", message.Method, message.Sender, err)
+		return true
+	}
+
+	if err := deliver(message.Sender, message.Method, params); err != nil {
+		m.attempts[message.Seq]++
+		if m.attempts[message.Seq] < MaxDeliveries {
+			m.logger.Warnf("failed to deliver %s from %s, retrying on the next sync: %v", message.Method, message.Sender, err)
+			return false
+		}
+		m.logger.Errorf("dropped %s from %s after %d deliveries: %v", message.Method, message.Sender, MaxDeliveries, err)
+	}
+
+	delete(m.attempts, message.Seq)
+	return true
+}
+
+// identityKeys looks up the identity keys of the senders of the messages.
+// They are looked up on every sync, since a party that lost its disk is given
+// the new key it registered once the operator repairs it.
+func (m *Mailbox) identityKeys(messages []sigagrpc.MailboxMessage) (sigagrpc.IdentityKeys, error) {
+	seen := make(map[string]bool)
+	var senders []string
+	for _, message := range messages {
+		if !seen[message.Sender] {
+			seen[message.Sender] = true
+			senders = append(senders, message.Sender)
+		}
+	}
+
+	return m.sigag.GetIdentityKeys(senders)
+}
+
+// open checks a message is addressed to this party and decrypts its params.
+func (m *Mailbox) open(keys sigagrpc.IdentityKeys, message sigagrpc.MailboxMessage) (json.RawMessage, error) {
+	if message.Recipient != m.id {
+		return nil, fmt.Errorf("message addressed to %s", message.Recipient)
+	}
+
+	sender, ok := keys[message.Sender]
+	if !ok {
+		return nil, fmt.Errorf("no identity key for %s", message.Sender)
+	}
+
+	return m.identity.Open(sender, message.Nonce, message.Payload, message.AAD())
+}
+
+// post seals the params of a call of `method` on the recipient and leaves
+// them at sigag.
+func (m *Mailbox) post(recipient string, epoch uint, method string, params interface{}) error {
+	plaintext, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	keys, err := m.sigag.GetIdentityKeys([]string{recipient})
+	if err != nil {
+		return err
+	}
+
+	message := sigagrpc.MailboxMessage{
+		Epoch:     epoch,
+		Method:    method,
+		Sender:    m.id,
+		Recipient: recipient,
+	}
+
+	message.Nonce, message.Payload, err = m.identity.Seal(keys[recipient], plaintext, message.AAD())
+	if err != nil {
+		return err
+	}
+	message.Signature = m.identity.Sign(message.SignedBytes())
+
+	return m.sigag.MailboxPost(message)
+}
+
+// peer implements rpc.Peer by posting to the peer's inbox.
+type peer struct {
+	mailbox *Mailbox
+	id      string
+}
+
+func (p peer) ID() string {
+	return p.id
+}
+
+func (p peer) DKGRound1Package(pkg dkg.Round1Package) error {
+	return p.mailbox.post(p.id, pkg.Epoch, "dkg_round1_package", rpc.DKGRound1PackageRequest{Package: pkg})
+}
+
+func (p peer) DKGRound2Share(share dkg.EncryptedShare) error {
+	return p.mailbox.post(p.id, share.Epoch, "dkg_round2_share", rpc.DKGRound2ShareRequest{Share: share})
+}
+
+func (p peer) RepairDelta(delta dkg.RepairDelta) error {
+	return p.mailbox.post(p.id, delta.Epoch, "repair_delta", rpc.RepairDeltaRequest{Delta: delta})
+}
+
+func (p peer) RepairSigma(sigma dkg.RepairSigma) error {
+	return p.mailbox.post(p.id, sigma.Epoch, "repair_sigma", rpc.RepairSigmaRequest{Sigma: sigma})
+}
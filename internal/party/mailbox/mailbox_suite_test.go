@@ -0,0 +1,13 @@
+package mailbox_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestMailbox(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Mailbox Suite")
+}
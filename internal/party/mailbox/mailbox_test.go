@@ -0,0 +1,195 @@
+package mailbox_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"frost/internal/party/dkg"
+	"frost/internal/party/identity"
+	"frost/internal/party/mailbox"
+	partyrpc "frost/internal/party/rpc"
+	sigagrpc "frost/internal/sigag/rpc"
+	client "frost/internal/sigag/sigagclient"
+	"frost/pkg/frost"
+	"frost/pkg/group"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+)
+
+// sigag keeps inboxes in memory and checks signatures like the sigag server.
+type sigag struct {
+	client.SigAgClient
+
+	keys    map[string]*identity.Key
+	inboxes map[string][]sigagrpc.MailboxMessage
+	seq     uint64
+}
+
+func (s *sigag) GetIdentityKeys(parties []string) (sigagrpc.IdentityKeys, error) {
+	keys := make(sigagrpc.IdentityKeys)
+	for _, id := range parties {
+		key, ok := s.keys[id]
+		if !ok {
+			return nil, fmt.Errorf("party %s registered no identity key", id)
+		}
+		keys[id] = key.PublicKey()
+	}
+	return keys, nil
+}
+
+func (s *sigag) MailboxPost(message sigagrpc.MailboxMessage) error {
+	if err := identity.Verify(s.keys[message.Sender].SigningKey(), message.SignedBytes(), message.Signature); err != nil {
+		return err
+	}
+
+	s.seq++
+	message.Seq = s.seq
+	s.inboxes[message.Recipient] = append(s.inboxes[message.Recipient], message)
+	return nil
+}
+
+func (s *sigag) MailboxFetch(request sigagrpc.MailboxFetchRequest) ([]sigagrpc.MailboxMessage, error) {
+	if err := identity.Verify(s.keys[request.Party].SigningKey(), request.SignedBytes(), request.Signature); err != nil {
+		return nil, err
+	}
+
+	var kept []sigagrpc.MailboxMessage
+	for _, message := range s.inboxes[request.Party] {
+		if message.Seq > request.After {
+			kept = append(kept, message)
+		}
+	}
+	s.inboxes[request.Party] = kept
+	return kept, nil
+}
+
+type delivery struct {
+	sender, method string
+	params         json.RawMessage
+}
+
+var _ = Describe("Mailbox", func() {
+	var (
+		server    *sigag
+		mailboxes map[string]*mailbox.Mailbox
+		delivered []delivery
+		deliver   partyrpc.Deliver
+		delta     dkg.RepairDelta
+	)
+
+	BeforeEach(func() {
+		server = &sigag{keys: make(map[string]*identity.Key), inboxes: make(map[string][]sigagrpc.MailboxMessage)}
+		mailboxes = make(map[string]*mailbox.Mailbox)
+		for _, id := range []string{"8801", "8802", "8803"} {
+			key, err := identity.Generate()
+			Expect(err).To(BeNil())
+			server.keys[id] = key
+			mailboxes[id] = mailbox.New(id, key, server, logrus.New())
+		}
+
+		delivered = nil
+		deliver = func(sender, method string, params json.RawMessage) error {
+			delivered = append(delivered, delivery{sender, method, params})
+			return nil
+		}
+
+		delta = dkg.RepairDelta{
+			Epoch:     1,
+			Lost:      "8803",
+			Sender:    "8801",
+			Recipient: "8802",
+			Value:     group.ScalarFromInt(frost.Secp256k1SHA256.Group(), 7),
+		}
+	})
+
+	It("should deliver a message through sigag without it reading the params", func() {
+		Expect(mailboxes["8801"].Dial("8802", "").RepairDelta(delta)).To(Succeed())
+
+		posted := server.inboxes["8802"][0]
+		Expect(string(posted.Payload)).ToNot(ContainSubstring("8803"))
+
+		Expect(mailboxes["8802"].Sync(deliver)).To(Succeed())
+		Expect(delivered).To(HaveLen(1))
+		Expect(delivered[0].sender).To(Equal("8801"))
+		Expect(delivered[0].method).To(Equal("repair_delta"))
+
+		var request partyrpc.RepairDeltaRequest
+		Expect(json.Unmarshal(delivered[0].params, &request)).To(Succeed())
+		Expect(request.Delta.Value.Equal(delta.Value)).To(BeTrue())
+	})
+
+	It("should drop handled messages from the inbox on the next sync", func() {
+		Expect(mailboxes["8801"].Dial("8802", "").RepairDelta(delta)).To(Succeed())
+		Expect(mailboxes["8802"].Sync(deliver)).To(Succeed())
+		Expect(mailboxes["8802"].Sync(deliver)).To(Succeed())
+
+		Expect(delivered).To(HaveLen(1))
+		Expect(server.inboxes["8802"]).To(BeEmpty())
+	})
+
+	It("should deliver refused messages again without redelivering the ones after them", func() {
+		other := delta
+		other.Sender = "8803"
+		Expect(mailboxes["8801"].Dial("8802", "").RepairDelta(delta)).To(Succeed())
+		Expect(mailboxes["8803"].Dial("8802", "").RepairDelta(other)).To(Succeed())
+
+		refuse := true
+		retrying := func(sender, method string, params json.RawMessage) error {
+			if sender == "8801" && refuse {
+				return fmt.Errorf("no participant for epoch 1")
+			}
+			return deliver(sender, method, params)
+		}
+
+		Expect(mailboxes["8802"].Sync(retrying)).To(Succeed())
+		Expect(delivered).To(HaveLen(1))
+		Expect(delivered[0].sender).To(Equal("8803"))
+		Expect(server.inboxes["8802"]).To(HaveLen(2))
+
+		refuse = false
+		Expect(mailboxes["8802"].Sync(retrying)).To(Succeed())
+		Expect(delivered).To(HaveLen(2))
+		Expect(delivered[1].sender).To(Equal("8801"))
+
+		Expect(mailboxes["8802"].Sync(retrying)).To(Succeed())
+		Expect(delivered).To(HaveLen(2))
+		Expect(server.inboxes["8802"]).To(BeEmpty())
+	})
+
+	It("should drop a message the party keeps refusing", func() {
+		Expect(mailboxes["8801"].Dial("8802", "").RepairDelta(delta)).To(Succeed())
+
+		refusals := 0
+		refusing := func(string, string, json.RawMessage) error {
+			refusals++
+			return fmt.Errorf("refused")
+		}
+
+		for i := 0; i < mailbox.MaxDeliveries+2; i++ {
+			Expect(mailboxes["8802"].Sync(refusing)).To(Succeed())
+		}
+		Expect(refusals).To(Equal(mailbox.MaxDeliveries))
+		Expect(server.inboxes["8802"]).To(BeEmpty())
+	})
+
+	It("should drop messages that do not open", func() {
+		Expect(mailboxes["8801"].Dial("8802", "").RepairDelta(delta)).To(Succeed())
+		server.inboxes["8802"][0].Payload[0] ^= 1
+
+		// a message 8803 seals to 8802 but addresses as coming from 8801
+		forged := sigagrpc.MailboxMessage{Seq: 10, Epoch: 1, Method: "repair_delta", Sender: "8801", Recipient: "8802"}
+		var err error
+		forged.Nonce, forged.Payload, err = server.keys["8803"].Seal(server.keys["8802"].PublicKey(), []byte("{}"), forged.AAD())
+		Expect(err).To(BeNil())
+		server.inboxes["8802"] = append(server.inboxes["8802"], forged)
+
+		Expect(mailboxes["8802"].Sync(deliver)).To(Succeed())
+		Expect(delivered).To(BeEmpty())
+	})
+
+	It("should not post messages sigag cannot attribute to the sender", func() {
+		impostor := mailbox.New("8801", server.keys["8803"], server, logrus.New())
+		Expect(impostor.Dial("8802", "").RepairDelta(delta)).ToNot(Succeed())
+	})
+})
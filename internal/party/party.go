@@ -3,26 +3,45 @@ package party
 import (
 	"context"
 	"fmt"
+	"frost/internal/party/mailbox"
+	"frost/internal/party/partyclient"
 	"frost/internal/party/rpc"
 	"frost/internal/party/store"
 	client "frost/internal/sigag/sigagclient"
 
+	"github.com/rosedblabs/rosedb/v2"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 )
 
-func SpinNewParty(port string, ServerUrl string, noTLS bool, logger *logrus.Logger) error {
+// SpinNewParty runs a party that keeps its identity and key material in `db`,
+// so it survives a restart. Parties call each other directly, or when they sit
+// `behindNAT` leave their messages at sigag, which every party of the
+// deployment must then do.
+func SpinNewParty(port string, ServerUrl string, noTLS bool, behindNAT bool, db *rosedb.DB, logger *logrus.Logger) error {
 
 	errs, _ := errgroup.WithContext(context.Background())
 
-	store := store.New()
+	store := store.New(db)
 	SigAgClient := client.New(ServerUrl)
 
+	identity, err := store.Identity()
+	if err != nil {
+		return err
+	}
+
+	var transport rpc.Transport = rpc.PeerDialer(func(id, url string) rpc.Peer {
+		return partyclient.NewFromURL(id, url)
+	})
+	if behindNAT {
+		transport = mailbox.New(port, identity, SigAgClient, logger)
+	}
+
 	errs.Go(func() error {
-		return rpc.NewServer(store, logger, SigAgClient).Run(port)
+		return rpc.NewServer(port, identity, store, logger, SigAgClient, transport).Run(port)
 	})
 
-	if err := SigAgClient.Register(port, fmt.Sprintf("127.0.0.1:%s%s", port, "/"), noTLS); err != nil {
+	if err := SigAgClient.Register(port, fmt.Sprintf("127.0.0.1:%s%s", port, "/"), identity.PublicKey(), identity.SigningKey(), noTLS); err != nil {
 		return err
 	}
 
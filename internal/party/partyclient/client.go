@@ -4,10 +4,14 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"frost/internal/dealer"
+	"frost/internal/party/dkg"
 	"frost/internal/party/rpc"
-	sigagrpc "frost/internal/sigag/rpc"
+	"frost/pkg/frost"
+	"frost/pkg/group"
 	"frost/pkg/types"
 	"net/http"
+	"strings"
 )
 
 type PartyClient interface {
@@ -16,7 +20,28 @@ type PartyClient interface {
 	Locate() (string, string)
 
 	NewEpoch(epoch uint) error
-	DKGInit(partyMap sigagrpc.Parties, threshold uint) error
+	DKGInit(dkgInit rpc.DKGInitRequest) error
+	DKGRound1(epoch uint, disqualified []string) (*dkg.Round1Package, error)
+	DKGRound1Digests(epoch uint) (map[string]string, error)
+	DKGRound1Received(epoch uint, dealer string) (dkg.Round1Package, error)
+	DKGRound2(epoch uint, disqualified []string) error
+	DKGRevealShare(epoch uint, recipient string) (dkg.Round2Share, error)
+	DKGDeliveries(epoch uint) (dkg.Deliveries, error)
+	DKGFinalize(epoch uint, disqualified []string) (rpc.DKGFinalizeResponse, error)
+	Preprocess(epoch uint, count uint) ([]frost.NonceCommitment, error)
+	Sign(epoch uint, pkg frost.SigningPackage) (*group.Scalar, error)
+	RetireEpoch(epoch uint) error
+	ImportKey(bundle dealer.Bundle, passphrase string) (rpc.DKGFinalizeResponse, error)
+	RepairShare(recovery dkg.Recovery, nonceIndex uint) error
+	RepairRound1(repair dkg.Repair) error
+	RepairRound2(repair dkg.Repair) error
+	RepairFinalize(epoch uint) (rpc.DKGFinalizeResponse, error)
+
+	// peer to peer
+	DKGRound1Package(pkg dkg.Round1Package) error
+	DKGRound2Share(share dkg.EncryptedShare) error
+	RepairDelta(delta dkg.RepairDelta) error
+	RepairSigma(sigma dkg.RepairSigma) error
 }
 
 type partyclient struct {
@@ -34,6 +59,15 @@ func New(id, url string, noTLS bool) PartyClient {
 	return &partyclient{id: id, url: url, connection: connection}
 }
 
+// NewFromURL builds a client from a url that already carries its scheme, as
+// handed out in sigag party maps.
+func NewFromURL(id, url string) PartyClient {
+	if strings.HasPrefix(url, "http://") {
+		return New(id, strings.TrimPrefix(url, "http://"), true)
+	}
+	return New(id, strings.TrimPrefix(url, "https://"), false)
+}
+
 func (c *partyclient) Ping() error {
 	var PingMessage rpc.PingMessage
 	return c.SendRequest("ping", nil, PingMessage)
@@ -58,17 +92,224 @@ func (c *partyclient) NewEpoch(epoch uint) error {
 	return nil
 }
 
-func (c *partyclient) DKGInit(partyMap sigagrpc.Parties, threshold uint) error {
-	dkgInit := rpc.DKGInitRequest{
-		Parties:   partyMap,
-		Threshold: threshold,
-	}
+func (c *partyclient) DKGInit(dkgInit rpc.DKGInitRequest) error {
 	if err := c.SendRequest("dkg_init", dkgInit, nil); err != nil {
 		return err
 	}
 	return nil
 }
 
+func (c *partyclient) DKGRound1(epoch uint, disqualified []string) (*dkg.Round1Package, error) {
+	round1 := rpc.DKGRound1Request{
+		Epoch:        epoch,
+		Disqualified: disqualified,
+	}
+	var response rpc.DKGRound1Response
+	if err := c.SendRequest("dkg_round1", round1, &response); err != nil {
+		return nil, err
+	}
+	return response.Package, nil
+}
+
+// DKGRound1Digests implements PartyClient.
+func (c *partyclient) DKGRound1Digests(epoch uint) (map[string]string, error) {
+	request := rpc.DKGRound1DigestsRequest{
+		Epoch: epoch,
+	}
+	var digests map[string]string
+	if err := c.SendRequest("dkg_round1_digests", request, &digests); err != nil {
+		return nil, err
+	}
+	return digests, nil
+}
+
+// DKGRound1Received implements PartyClient.
+func (c *partyclient) DKGRound1Received(epoch uint, dealer string) (dkg.Round1Package, error) {
+	request := rpc.DKGRound1ReceivedRequest{
+		Epoch:  epoch,
+		Dealer: dealer,
+	}
+	var pkg dkg.Round1Package
+	if err := c.SendRequest("dkg_round1_received", request, &pkg); err != nil {
+		return dkg.Round1Package{}, err
+	}
+	return pkg, nil
+}
+
+func (c *partyclient) DKGRound1Package(pkg dkg.Round1Package) error {
+	request := rpc.DKGRound1PackageRequest{
+		Package: pkg,
+	}
+	if err := c.SendRequest("dkg_round1_package", request, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *partyclient) DKGRound2(epoch uint, disqualified []string) error {
+	round2 := rpc.DKGRound2Request{
+		Epoch:        epoch,
+		Disqualified: disqualified,
+	}
+	if err := c.SendRequest("dkg_round2", round2, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *partyclient) DKGRevealShare(epoch uint, recipient string) (dkg.Round2Share, error) {
+	reveal := rpc.DKGRevealShareRequest{
+		Epoch:     epoch,
+		Recipient: recipient,
+	}
+	var share dkg.Round2Share
+	if err := c.SendRequest("dkg_reveal_share", reveal, &share); err != nil {
+		return dkg.Round2Share{}, err
+	}
+	return share, nil
+}
+
+func (c *partyclient) DKGDeliveries(epoch uint) (dkg.Deliveries, error) {
+	request := rpc.DKGDeliveriesRequest{
+		Epoch: epoch,
+	}
+	var response dkg.Deliveries
+	if err := c.SendRequest("dkg_deliveries", request, &response); err != nil {
+		return dkg.Deliveries{}, err
+	}
+	return response, nil
+}
+
+func (c *partyclient) DKGFinalize(epoch uint, disqualified []string) (rpc.DKGFinalizeResponse, error) {
+	finalize := rpc.DKGFinalizeRequest{
+		Epoch:        epoch,
+		Disqualified: disqualified,
+	}
+	var response rpc.DKGFinalizeResponse
+	if err := c.SendRequest("dkg_finalize", finalize, &response); err != nil {
+		return rpc.DKGFinalizeResponse{}, err
+	}
+	return response, nil
+}
+
+func (c *partyclient) Preprocess(epoch uint, count uint) ([]frost.NonceCommitment, error) {
+	preprocess := rpc.PreprocessRequest{
+		Epoch: epoch,
+		Count: count,
+	}
+	var response rpc.PreprocessResponse
+	if err := c.SendRequest("preprocess", preprocess, &response); err != nil {
+		return nil, err
+	}
+	return response.Commitments, nil
+}
+
+func (c *partyclient) Sign(epoch uint, pkg frost.SigningPackage) (*group.Scalar, error) {
+	sign := rpc.SignRequest{
+		Epoch:   epoch,
+		Package: pkg,
+	}
+	var response rpc.SignResponse
+	if err := c.SendRequest("sign", sign, &response); err != nil {
+		return nil, err
+	}
+	return response.Share, nil
+}
+
+func (c *partyclient) RetireEpoch(epoch uint) error {
+	retire := rpc.RetireEpochRequest{
+		Epoch: epoch,
+	}
+	if err := c.SendRequest("retire_epoch", retire, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *partyclient) ImportKey(bundle dealer.Bundle, passphrase string) (rpc.DKGFinalizeResponse, error) {
+	request := rpc.ImportKeyRequest{
+		Bundle:     bundle,
+		Passphrase: passphrase,
+	}
+	var response rpc.DKGFinalizeResponse
+	if err := c.SendRequest("import_key", request, &response); err != nil {
+		return rpc.DKGFinalizeResponse{}, err
+	}
+	return response, nil
+}
+
+func (c *partyclient) RepairShare(recovery dkg.Recovery, nonceIndex uint) error {
+	request := rpc.RepairShareRequest{
+		Recovery:   recovery,
+		NonceIndex: nonceIndex,
+	}
+	if err := c.SendRequest("repair_share", request, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *partyclient) RepairRound1(repair dkg.Repair) error {
+	request := rpc.RepairRequest{
+		Repair: repair,
+	}
+	if err := c.SendRequest("repair_round1", request, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *partyclient) RepairRound2(repair dkg.Repair) error {
+	request := rpc.RepairRequest{
+		Repair: repair,
+	}
+	if err := c.SendRequest("repair_round2", request, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *partyclient) RepairFinalize(epoch uint) (rpc.DKGFinalizeResponse, error) {
+	finalize := rpc.RepairFinalizeRequest{
+		Epoch: epoch,
+	}
+	var response rpc.DKGFinalizeResponse
+	if err := c.SendRequest("repair_finalize", finalize, &response); err != nil {
+		return rpc.DKGFinalizeResponse{}, err
+	}
+	return response, nil
+}
+
+func (c *partyclient) RepairDelta(delta dkg.RepairDelta) error {
+	request := rpc.RepairDeltaRequest{
+		Delta: delta,
+	}
+	if err := c.SendRequest("repair_delta", request, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *partyclient) RepairSigma(sigma dkg.RepairSigma) error {
+	request := rpc.RepairSigmaRequest{
+		Sigma: sigma,
+	}
+	if err := c.SendRequest("repair_sigma", request, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *partyclient) DKGRound2Share(share dkg.EncryptedShare) error {
+	request := rpc.DKGRound2ShareRequest{
+		Share: share,
+	}
+	if err := c.SendRequest("dkg_round2_share", request, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (c *partyclient) SendRequest(method string, params, respType interface{}) error {
 
 	paramsData, err := json.Marshal(params)
@@ -1,7 +1,11 @@
 package rpc
 
 import (
+	"frost/internal/dealer"
+	"frost/internal/party/dkg"
 	sigagrpc "frost/internal/sigag/rpc"
+	"frost/pkg/frost"
+	"frost/pkg/group"
 )
 
 type PingMessage struct {
@@ -13,6 +17,171 @@ type NewEpochRequest struct {
 }
 
 type DKGInitRequest struct {
-	Parties   sigagrpc.Parties `json:"epoch,strict_check"`
-	Threshold uint             `json:"threshold,strict_check"`
+	Epoch       uint             `json:"epoch,strict_check"`
+	Ciphersuite string           `json:"ciphersuite,strict_check"`
+	Parties     sigagrpc.Parties `json:"parties,strict_check"`
+	Threshold   uint             `json:"threshold,strict_check"`
+
+	// IdentityKeys are the public identity keys of the parties, which
+	// round 2 shares are sealed to.
+	IdentityKeys sigagrpc.IdentityKeys `json:"identity_keys,strict_check"`
+	// SigningKeys are the public signing keys of the parties, which round 1
+	// packages are signed with.
+	SigningKeys map[string]string `json:"signing_keys,strict_check"`
+	// Identifiers are the scalar identifiers of the parties and, in a
+	// resharing, of the previous committee. Every party derives them itself
+	// and refuses the dkg when they differ.
+	Identifiers map[string]*group.Scalar `json:"identifiers,strict_check"`
+
+	// Mode is one of the sigag epoch modes and defaults to a new key.
+	Mode string `json:"mode"`
+	// PreviousEpoch is the epoch whose key a refresh re-randomizes or a
+	// resharing hands over.
+	PreviousEpoch uint `json:"previous_epoch"`
+	// Resharing describes the previous committee when Mode is reshare.
+	Resharing *dkg.Resharing `json:"resharing"`
+}
+
+// AllParties are the parties of the dkg and, in a resharing, of the previous
+// committee, which is everyone the dkg needs an identifier for.
+func (r DKGInitRequest) AllParties() sigagrpc.Parties {
+	parties := make(sigagrpc.Parties, len(r.Parties))
+	for id, url := range r.Parties {
+		parties[id] = url
+	}
+
+	if r.Resharing != nil {
+		for id, url := range r.Resharing.PreviousParties {
+			if _, ok := parties[id]; !ok {
+				parties[id] = url
+			}
+		}
+	}
+
+	return parties
+}
+
+type DKGRound1Request struct {
+	Epoch uint `json:"epoch,strict_check"`
+
+	// Disqualified are the parties dropped from the dkg so far.
+	Disqualified []string `json:"disqualified"`
+}
+
+// DKGRound1Response hands sigag the round 1 package a dealer broadcast, so
+// complaints about its shares can be judged. New members of a resharing
+// broadcast none.
+type DKGRound1Response struct {
+	Package *dkg.Round1Package `json:"package"`
+}
+
+type DKGRound1PackageRequest struct {
+	Package dkg.Round1Package `json:"package,strict_check"`
+}
+
+// DKGRound1DigestsRequest asks a party for the digests of the round 1
+// packages it received, so sigag can check every dealer broadcast the same
+// package.
+type DKGRound1DigestsRequest struct {
+	Epoch uint `json:"epoch,strict_check"`
+}
+
+// DKGRound1ReceivedRequest asks a party to show the round 1 package it
+// received from a dealer.
+type DKGRound1ReceivedRequest struct {
+	Epoch  uint   `json:"epoch,strict_check"`
+	Dealer string `json:"dealer,strict_check"`
+}
+
+type DKGRound2Request struct {
+	Epoch uint `json:"epoch,strict_check"`
+
+	// Disqualified are the parties dropped from the dkg so far.
+	Disqualified []string `json:"disqualified"`
+}
+
+type DKGRound2ShareRequest struct {
+	Share dkg.EncryptedShare `json:"share,strict_check"`
+}
+
+// DKGRevealShareRequest asks a dealer to publish the share it sent a party
+// that complained about it.
+type DKGRevealShareRequest struct {
+	Epoch     uint   `json:"epoch,strict_check"`
+	Recipient string `json:"recipient,strict_check"`
+}
+
+// DKGDeliveriesRequest asks which dealers of an epoch's dkg a party still
+// waits for.
+type DKGDeliveriesRequest struct {
+	Epoch uint `json:"epoch,strict_check"`
+}
+
+type DKGFinalizeRequest struct {
+	Epoch uint `json:"epoch,strict_check"`
+
+	// Disqualified are the parties whose shares no longer count.
+	Disqualified []string `json:"disqualified"`
+}
+
+// RetireEpochRequest asks a party to erase its key material for an epoch
+// whose shares were refreshed.
+type RetireEpochRequest struct {
+	Epoch uint `json:"epoch,strict_check"`
+}
+
+type DKGFinalizeResponse struct {
+	GroupPublicKey    *group.Element `json:"group_public_key"`
+	VerificationShare *group.Element `json:"verification_share"`
+}
+
+// MaxPreprocessBatch caps the number of nonces generated by a single request.
+const MaxPreprocessBatch = 100
+
+type PreprocessRequest struct {
+	Epoch uint `json:"epoch,strict_check"`
+	Count uint `json:"count,strict_check"`
+}
+
+type PreprocessResponse struct {
+	Commitments []frost.NonceCommitment `json:"commitments"`
+}
+
+type SignRequest struct {
+	Epoch   uint                 `json:"epoch,strict_check"`
+	Package frost.SigningPackage `json:"package,strict_check"`
+}
+
+type SignResponse struct {
+	Share *group.Scalar `json:"share"`
+}
+
+// ImportKeyRequest carries a trusted dealer's bundle for this party and the
+// passphrase it is sealed under.
+type ImportKeyRequest struct {
+	Bundle     dealer.Bundle `json:"bundle,strict_check"`
+	Passphrase string        `json:"passphrase,strict_check"`
+}
+
+// RepairShareRequest starts the repair of the share this party lost.
+// NonceIndex is the first nonce index sigag has not seen from the party yet.
+type RepairShareRequest struct {
+	Recovery   dkg.Recovery `json:"recovery,strict_check"`
+	NonceIndex uint         `json:"nonce_index"`
+}
+
+type RepairRequest struct {
+	Repair dkg.Repair `json:"repair,strict_check"`
+}
+
+type RepairDeltaRequest struct {
+	Delta dkg.RepairDelta `json:"delta,strict_check"`
+}
+
+type RepairSigmaRequest struct {
+	Sigma dkg.RepairSigma `json:"sigma,strict_check"`
+}
+
+type RepairFinalizeRequest struct {
+	Epoch uint `json:"epoch,strict_check"`
 }
@@ -4,9 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"frost/internal/party/dkg"
+	"frost/internal/party/identity"
+	sigagrpc "frost/internal/sigag/rpc"
 	client "frost/internal/sigag/sigagclient"
+	"frost/pkg/frost"
 	"frost/pkg/rpc"
 	"reflect"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -15,22 +20,85 @@ import (
 )
 
 type server struct {
-	logger *logrus.Logger
-	router *gin.Engine
+	id       string
+	identity *identity.Key
+	logger   *logrus.Logger
+	router   *gin.Engine
 
 	SigAgClient client.SigAgClient
 	store       Store
+	transport   Transport
 }
 
 type Store interface {
-	Lock()
-	UnLock()
+	Lock() error
 	IsLocked() bool
 	NewEpoch(epoch uint) error
+	CurrentEpoch() uint
+
+	PutParticipant(participant *dkg.Participant, pkg *dkg.Round1Package) error
+	UpdateParticipant(participant *dkg.Participant) error
+	GetParticipant(epoch uint) (*dkg.Participant, error)
+	PutRound1Package(pkg dkg.Round1Package) error
+	GetRound1Packages(epoch uint) (map[string]dkg.Round1Package, error)
+	PutRound2Share(share dkg.Round2Share) error
+	GetRound2Shares(epoch uint) (map[string]dkg.Round2Share, error)
+	FinishDKG(participant *dkg.Participant, key *dkg.KeyPackage) error
+	PutKeyPackage(key *dkg.KeyPackage) error
+	GetKeyPackage(epoch uint) (*dkg.KeyPackage, error)
+	RetireEpoch(epoch uint) error
+
+	NextNonceIndex(epoch uint) (uint, error)
+	PutNonces(epoch uint, nonces []*frost.Nonce) error
+	TakeNonce(epoch uint, index uint) (*frost.Nonce, error)
+	ResumeNonces(epoch uint, next uint) error
+
+	PutRecovery(recovery *dkg.Recovery) error
+	GetRecovery(epoch uint) (*dkg.Recovery, error)
+	FinishRepair(key *dkg.KeyPackage) error
+	PutRepairSigma(sigma dkg.RepairSigma) error
+	GetRepairSigmas(epoch uint) (map[string]dkg.RepairSigma, error)
+	PutRepairDelta(delta dkg.RepairDelta) error
+	TakeRepairDeltas(epoch uint, lost string) (map[string]dkg.RepairDelta, error)
+}
+
+// Peer sends messages to another party through the transport. The party
+// client is the Peer of parties that reach each other directly.
+type Peer interface {
+	ID() string
+	DKGRound1Package(pkg dkg.Round1Package) error
+	DKGRound2Share(share dkg.EncryptedShare) error
+	RepairDelta(delta dkg.RepairDelta) error
+	RepairSigma(sigma dkg.RepairSigma) error
+}
+
+// Transport carries the messages parties send each other. Messages that do
+// not reach the party right away wait until the party syncs, which it does
+// before every step that reads them.
+type Transport interface {
+	Dial(id, url string) Peer
+	Sync(deliver Deliver) error
+}
+
+// Deliver hands a message `sender` sent to the party's handler of `method`.
+type Deliver func(sender, method string, params json.RawMessage) error
+
+// PeerDialer is the Transport of parties that reach each other directly. The
+// parties are dialed at the urls of the Parties map sigag hands out.
+type PeerDialer func(id, url string) Peer
+
+// Dial implements Transport.
+func (d PeerDialer) Dial(id, url string) Peer {
+	return d(id, url)
+}
+
+// Sync implements Transport. Direct messages were delivered when they were sent.
+func (d PeerDialer) Sync(Deliver) error {
+	return nil
 }
 
-func NewServer(store Store, logger *logrus.Logger, SigAgClient client.SigAgClient) *server {
-	return &server{store: store, router: gin.New(), logger: logger, SigAgClient: SigAgClient}
+func NewServer(id string, identity *identity.Key, store Store, logger *logrus.Logger, SigAgClient client.SigAgClient, transport Transport) *server {
+	return &server{id: id, identity: identity, store: store, router: gin.New(), logger: logger, SigAgClient: SigAgClient, transport: transport}
 }
 
 func (s *server) Run(port string) error {
@@ -89,7 +157,9 @@ func (s *server) Ping(_ context.Context, _ *json.RawMessage) (json.RawMessage, e
 }
 
 func (s *server) NewEpoch(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
-	s.store.Lock()
+	if err := s.store.Lock(); err != nil {
+		return nil, err
+	}
 
 	if len(*params) == 0 {
 		return nil, fmt.Errorf("params is nil")
@@ -129,5 +199,1012 @@ func (s *server) DkgInit(_ context.Context, params *json.RawMessage) (json.RawMe
 		return nil, err
 	}
 
+	if dkgInit.Epoch != s.store.CurrentEpoch() {
+		return nil, fmt.Errorf("dkg init for epoch %d, current epoch is %d", dkgInit.Epoch, s.store.CurrentEpoch())
+	}
+
+	cs, err := frost.CiphersuiteByID(dkgInit.Ciphersuite)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dkg.CheckIdentifiers(cs.Group(), dkgInit.AllParties(), dkgInit.Identifiers); err != nil {
+		return nil, err
+	}
+
+	participant, err := s.newParticipant(dkgInit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := participant.SetIdentityKeys(dkgInit.IdentityKeys, s.identity.PublicKey()); err != nil {
+		return nil, err
+	}
+
+	if err := participant.SetSigningKeys(dkgInit.SigningKeys, s.identity.SigningKey()); err != nil {
+		return nil, err
+	}
+
+	// new members of a resharing only receive shares
+	var pkg *dkg.Round1Package
+	if participant.Dealer() {
+		own, err := participant.DkGRound1()
+		if err != nil {
+			return nil, err
+		}
+
+		if own, err = participant.SignRound1Package(s.identity, own); err != nil {
+			return nil, err
+		}
+		pkg = &own
+	}
+
+	if err := s.store.PutParticipant(participant, pkg); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(true)
+}
+
+// newParticipant starts a fresh DKG, a refresh of the key this party holds
+// for the previous epoch, or a resharing of a previous key to a new committee.
+func (s *server) newParticipant(dkgInit DKGInitRequest) (*dkg.Participant, error) {
+	switch dkgInit.Mode {
+	case "", sigagrpc.EpochModeNewKey:
+		cs, err := frost.CiphersuiteByID(dkgInit.Ciphersuite)
+		if err != nil {
+			return nil, err
+		}
+
+		return dkg.NewParticipant(dkgInit.Epoch, cs, s.id, dkgInit.Parties, dkgInit.Threshold)
+
+	case sigagrpc.EpochModeRefresh:
+		previous, err := s.store.GetKeyPackage(dkgInit.PreviousEpoch)
+		if err != nil {
+			return nil, err
+		}
+
+		if previous.Ciphersuite != dkgInit.Ciphersuite || previous.Threshold != dkgInit.Threshold {
+			return nil, fmt.Errorf("refresh of epoch %d must keep ciphersuite %s and threshold %d", previous.Epoch, previous.Ciphersuite, previous.Threshold)
+		}
+
+		return dkg.NewRefreshParticipant(dkgInit.Epoch, previous, dkgInit.Parties)
+
+	case sigagrpc.EpochModeReshare:
+		if dkgInit.Resharing == nil {
+			return nil, fmt.Errorf("resharing of epoch %d without the previous committee", dkgInit.PreviousEpoch)
+		}
+
+		cs, err := frost.CiphersuiteByID(dkgInit.Ciphersuite)
+		if err != nil {
+			return nil, err
+		}
+
+		var previous *dkg.KeyPackage
+		for _, dealer := range dkgInit.Resharing.Dealers {
+			if dealer != s.id {
+				continue
+			}
+
+			if previous, err = s.store.GetKeyPackage(dkgInit.PreviousEpoch); err != nil {
+				return nil, err
+			}
+		}
+
+		return dkg.NewResharingParticipant(dkgInit.Epoch, cs, s.id, dkgInit.Parties, dkgInit.Threshold, dkgInit.Resharing, previous)
+
+	default:
+		return nil, fmt.Errorf("unknown dkg mode %s", dkgInit.Mode)
+	}
+}
+
+// DkgRound1 broadcasts this party's round 1 package to every other qualified
+// party and hands it to sigag.
+func (s *server) DkgRound1(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var round1 DKGRound1Request
+	if err := json.Unmarshal(*params, &round1); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(round1); err != nil {
+		return nil, err
+	}
+
+	participant, err := s.store.GetParticipant(round1.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := participant.Disqualify(round1.Disqualified); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.UpdateParticipant(participant); err != nil {
+		return nil, err
+	}
+
+	if !participant.Dealer() {
+		return json.Marshal(DKGRound1Response{})
+	}
+
+	packages, err := s.store.GetRound1Packages(round1.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, ok := packages[s.id]
+	if !ok {
+		return nil, fmt.Errorf("round 1 package for epoch %d not found", round1.Epoch)
+	}
+
+	for id, url := range participant.Parties {
+		if id == s.id || participant.Disqualified[id] {
+			continue
+		}
+
+		peer := s.transport.Dial(id, url)
+		if err := retry(3, time.Second, func() error {
+			return peer.DKGRound1Package(pkg)
+		}); err != nil {
+			return nil, fmt.Errorf("failed to send round 1 package to %s: %w", id, err)
+		}
+	}
+
+	return json.Marshal(DKGRound1Response{Package: &pkg})
+}
+
+// DkgRound1Package receives and verifies another party's round 1 package,
+// which must carry the sender's signature.
+func (s *server) DkgRound1Package(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var request DKGRound1PackageRequest
+	if err := json.Unmarshal(*params, &request); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(request); err != nil {
+		return nil, err
+	}
+
+	participant, err := s.store.GetParticipant(request.Package.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Package.Sender == s.id {
+		return nil, fmt.Errorf("received own round 1 package")
+	}
+
+	if err := participant.VerifyRound1Package(request.Package); err != nil {
+		s.logger.Errorf("rejected round 1 package from %s: %v", request.Package.Sender, err)
+		return nil, err
+	}
+
+	if err := participant.VerifyRound1Signature(request.Package); err != nil {
+		s.logger.Errorf("rejected round 1 package from %s: %v", request.Package.Sender, err)
+		return nil, err
+	}
+
+	if err := s.store.PutRound1Package(request.Package); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(true)
+}
+
+// DkgRound1Digests reports the digest of every round 1 package this party
+// received, after handling the messages waiting in its inbox.
+func (s *server) DkgRound1Digests(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var request DKGRound1DigestsRequest
+	if err := json.Unmarshal(*params, &request); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(request); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.store.GetParticipant(request.Epoch); err != nil {
+		return nil, err
+	}
+
+	if err := s.sync(); err != nil {
+		return nil, err
+	}
+
+	packages, err := s.store.GetRound1Packages(request.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	digests := make(map[string]string, len(packages))
+	for id, pkg := range packages {
+		digests[id] = pkg.Digest()
+	}
+
+	return json.Marshal(digests)
+}
+
+// DkgRound1Received shows the signed round 1 package this party received from
+// a dealer, when sigag holds another package from it.
+func (s *server) DkgRound1Received(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var request DKGRound1ReceivedRequest
+	if err := json.Unmarshal(*params, &request); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(request); err != nil {
+		return nil, err
+	}
+
+	packages, err := s.store.GetRound1Packages(request.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, ok := packages[request.Dealer]
+	if !ok {
+		return nil, fmt.Errorf("no round 1 package from %s in epoch %d", request.Dealer, request.Epoch)
+	}
+
+	return json.Marshal(pkg)
+}
+
+// DkgRound2 seals every other qualified party its secret share of this party's
+// polynomial and sends it.
+func (s *server) DkgRound2(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var round2 DKGRound2Request
+	if err := json.Unmarshal(*params, &round2); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(round2); err != nil {
+		return nil, err
+	}
+
+	participant, err := s.store.GetParticipant(round2.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.sync(); err != nil {
+		return nil, err
+	}
+
+	if err := participant.Disqualify(round2.Disqualified); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.UpdateParticipant(participant); err != nil {
+		return nil, err
+	}
+
+	packages, err := s.store.GetRound1Packages(round2.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	shares, err := participant.DkGRound2(packages)
+	if err != nil {
+		return nil, err
+	}
+
+	// a recipient that rejects its share complains to sigag, so keep
+	// delivering to the others and let sigag judge
+	var failed []string
+	for _, share := range shares {
+		sealed, err := participant.SealShare(s.identity, share)
+		if err != nil {
+			return nil, err
+		}
+
+		peer := s.transport.Dial(share.Recipient, participant.Parties[share.Recipient])
+		if err := retry(3, time.Second, func() error {
+			return peer.DKGRound2Share(sealed)
+		}); err != nil {
+			s.logger.Errorf("failed to send round 2 share to %s: %v", share.Recipient, err)
+			failed = append(failed, share.Recipient)
+		}
+	}
+
+	if len(failed) > 0 {
+		return nil, fmt.Errorf("failed to send round 2 shares to %v", failed)
+	}
+
+	return json.Marshal(true)
+}
+
+// DkgRound2Share opens another party's sealed secret share and verifies it
+// against the sender's round 1 commitments. A share that opens but does not
+// match them is reported to sigag as a complaint against the sender.
+func (s *server) DkgRound2Share(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var request DKGRound2ShareRequest
+	if err := json.Unmarshal(*params, &request); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(request); err != nil {
+		return nil, err
+	}
+
+	participant, err := s.store.GetParticipant(request.Share.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	// anyone could have sent a share that does not open, so there is
+	// nobody to complain about
+	share, err := participant.OpenShare(s.identity, request.Share)
+	if err != nil {
+		s.logger.Errorf("rejected sealed share: %v", err)
+		return nil, err
+	}
+
+	packages, err := s.store.GetRound1Packages(share.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	sender, ok := packages[share.Sender]
+	if !ok {
+		return nil, fmt.Errorf("no round 1 package from %s", share.Sender)
+	}
+
+	if err := participant.VerifyRound2Share(share, sender); err != nil {
+		s.logger.Errorf("rejected round 2 share from %s: %v", share.Sender, err)
+		s.complain(share, err)
+		return nil, err
+	}
+
+	if err := s.store.PutRound2Share(share); err != nil {
+		return nil, err
+	}
+
 	return json.Marshal(true)
 }
+
+// complain files a complaint with sigag about a share addressed to this party.
+func (s *server) complain(share dkg.Round2Share, reason error) {
+	if share.Recipient != s.id {
+		return
+	}
+
+	complaint := sigagrpc.DKGComplaint{
+		Epoch:   share.Epoch,
+		Accuser: s.id,
+		Accused: share.Sender,
+		Reason:  reason.Error(),
+	}
+	if err := s.SigAgClient.DKGComplaint(complaint); err != nil {
+		s.logger.Errorf("failed to complain about the round 2 share from %s: %v", share.Sender, err)
+	}
+}
+
+// DkgRevealShare publishes the share this party sent a party that complained
+// about it, for sigag to check against its round 1 commitments.
+func (s *server) DkgRevealShare(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var reveal DKGRevealShareRequest
+	if err := json.Unmarshal(*params, &reveal); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(reveal); err != nil {
+		return nil, err
+	}
+
+	participant, err := s.store.GetParticipant(reveal.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	share, err := participant.RevealShare(reveal.Recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(share)
+}
+
+// DkgDeliveries reports the round 1 packages and round 2 shares this party
+// still waits for, after handling the messages waiting in its inbox.
+func (s *server) DkgDeliveries(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var request DKGDeliveriesRequest
+	if err := json.Unmarshal(*params, &request); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(request); err != nil {
+		return nil, err
+	}
+
+	participant, err := s.store.GetParticipant(request.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.sync(); err != nil {
+		return nil, err
+	}
+
+	packages, err := s.store.GetRound1Packages(request.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	shares, err := s.store.GetRound2Shares(request.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(participant.Awaiting(packages, shares))
+}
+
+// DkgFinalize derives this party's long-lived key material from the shares of
+// the qualified dealers, and reports the public half of it.
+func (s *server) DkgFinalize(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var finalize DKGFinalizeRequest
+	if err := json.Unmarshal(*params, &finalize); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(finalize); err != nil {
+		return nil, err
+	}
+
+	participant, err := s.store.GetParticipant(finalize.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.sync(); err != nil {
+		return nil, err
+	}
+
+	if err := participant.Disqualify(finalize.Disqualified); err != nil {
+		return nil, err
+	}
+
+	packages, err := s.store.GetRound1Packages(finalize.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	shares, err := s.store.GetRound2Shares(finalize.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := participant.Finalize(packages, shares)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.FinishDKG(participant, key); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(DKGFinalizeResponse{
+		GroupPublicKey:    key.GroupPublicKey,
+		VerificationShare: key.VerificationShare,
+	})
+}
+
+// RetireEpoch erases this party's signing share and unused nonces of an epoch
+// once its shares have been refreshed, so the old shares can no longer be
+// combined with anything. The key of the current epoch must already exist.
+func (s *server) RetireEpoch(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var retire RetireEpochRequest
+	if err := json.Unmarshal(*params, &retire); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(retire); err != nil {
+		return nil, err
+	}
+
+	current := s.store.CurrentEpoch()
+	if retire.Epoch >= current {
+		return nil, fmt.Errorf("cannot retire epoch %d while in epoch %d", retire.Epoch, current)
+	}
+
+	if _, err := s.store.GetKeyPackage(current); err != nil {
+		return nil, fmt.Errorf("keeping epoch %d: %w", retire.Epoch, err)
+	}
+
+	if err := s.store.RetireEpoch(retire.Epoch); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("retired key material of epoch %d", retire.Epoch)
+	return json.Marshal(true)
+}
+
+// ImportKey opens a trusted dealer's bundle and keeps the key package in it as
+// this party's key material for the bundle's epoch.
+func (s *server) ImportKey(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var request ImportKeyRequest
+	if err := json.Unmarshal(*params, &request); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(request); err != nil {
+		return nil, err
+	}
+
+	if request.Bundle.Party != s.id {
+		return nil, fmt.Errorf("bundle of %s sent to %s", request.Bundle.Party, s.id)
+	}
+
+	key, err := request.Bundle.Open([]byte(request.Passphrase))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.PutKeyPackage(key); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("imported dealt key %s for epoch %d", key.GroupPublicKey, key.Epoch)
+	return json.Marshal(DKGFinalizeResponse{
+		GroupPublicKey:    key.GroupPublicKey,
+		VerificationShare: key.VerificationShare,
+	})
+}
+
+// RepairShare starts rebuilding the share this party lost for an epoch. Sigag
+// hands over the public key material of the epoch and the helpers it picked,
+// the helpers then send their σ through repair_sigma.
+func (s *server) RepairShare(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var request RepairShareRequest
+	if err := json.Unmarshal(*params, &request); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(request); err != nil {
+		return nil, err
+	}
+
+	recovery := request.Recovery
+	if recovery.Lost != s.id {
+		return nil, fmt.Errorf("repair of %s sent to %s", recovery.Lost, s.id)
+	}
+
+	if err := recovery.Check(); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.PutRecovery(&recovery); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.ResumeNonces(recovery.Epoch, request.NonceIndex); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("repairing share of epoch %d with helpers %v", recovery.Epoch, recovery.Helpers)
+	return json.Marshal(true)
+}
+
+// RepairRound1 splits this helper's contribution to a lost share into random
+// deltas and sends one to every other helper.
+func (s *server) RepairRound1(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var request RepairRequest
+	if err := json.Unmarshal(*params, &request); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(request); err != nil {
+		return nil, err
+	}
+
+	key, err := s.store.GetKeyPackage(request.Repair.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	deltas, err := dkg.RepairDeltas(key, request.Repair)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, delta := range deltas {
+		if delta.Recipient == s.id {
+			if err := s.store.PutRepairDelta(delta); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		peer := s.transport.Dial(delta.Recipient, request.Repair.Parties[delta.Recipient])
+		delta := delta
+		if err := retry(3, time.Second, func() error {
+			return peer.RepairDelta(delta)
+		}); err != nil {
+			return nil, fmt.Errorf("failed to send repair delta to %s: %w", delta.Recipient, err)
+		}
+	}
+
+	return json.Marshal(true)
+}
+
+// RepairDelta receives another helper's delta for a lost share.
+func (s *server) RepairDelta(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var request RepairDeltaRequest
+	if err := json.Unmarshal(*params, &request); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(request); err != nil {
+		return nil, err
+	}
+
+	delta := request.Delta
+	if delta.Recipient != s.id || delta.Sender == s.id {
+		return nil, fmt.Errorf("repair delta from %s to %s", delta.Sender, delta.Recipient)
+	}
+
+	if delta.Value == nil {
+		return nil, fmt.Errorf("repair delta from %s has no value", delta.Sender)
+	}
+
+	if err := s.store.PutRepairDelta(delta); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(true)
+}
+
+// RepairRound2 sums the deltas this helper received and sends the σ to the
+// lost party. The deltas are erased once summed.
+func (s *server) RepairRound2(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var request RepairRequest
+	if err := json.Unmarshal(*params, &request); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(request); err != nil {
+		return nil, err
+	}
+
+	key, err := s.store.GetKeyPackage(request.Repair.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.sync(); err != nil {
+		return nil, err
+	}
+
+	deltas, err := s.store.TakeRepairDeltas(request.Repair.Epoch, request.Repair.Lost)
+	if err != nil {
+		return nil, err
+	}
+
+	sigma, err := dkg.RepairSigmaOf(key, request.Repair, deltas)
+	if err != nil {
+		return nil, err
+	}
+
+	peer := s.transport.Dial(request.Repair.Lost, request.Repair.Parties[request.Repair.Lost])
+	if err := retry(3, time.Second, func() error {
+		return peer.RepairSigma(sigma)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to send repair sigma to %s: %w", request.Repair.Lost, err)
+	}
+
+	return json.Marshal(true)
+}
+
+// RepairSigma receives a helper's σ for the share this party is repairing.
+func (s *server) RepairSigma(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var request RepairSigmaRequest
+	if err := json.Unmarshal(*params, &request); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(request); err != nil {
+		return nil, err
+	}
+
+	recovery, err := s.store.GetRecovery(request.Sigma.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recovery.VerifyRepairSigma(request.Sigma); err != nil {
+		s.logger.Errorf("rejected repair sigma from %s: %v", request.Sigma.Sender, err)
+		return nil, err
+	}
+
+	if err := s.store.PutRepairSigma(request.Sigma); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(true)
+}
+
+// RepairFinalize rebuilds the lost share from the σ of every helper and keeps
+// it once it matches the published verification share.
+func (s *server) RepairFinalize(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var finalize RepairFinalizeRequest
+	if err := json.Unmarshal(*params, &finalize); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(finalize); err != nil {
+		return nil, err
+	}
+
+	recovery, err := s.store.GetRecovery(finalize.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.sync(); err != nil {
+		return nil, err
+	}
+
+	sigmas, err := s.store.GetRepairSigmas(finalize.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := recovery.Finalize(sigmas)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.FinishRepair(key); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("repaired share of epoch %d", finalize.Epoch)
+	return json.Marshal(DKGFinalizeResponse{
+		GroupPublicKey:    key.GroupPublicKey,
+		VerificationShare: key.VerificationShare,
+	})
+}
+
+// Preprocess generates a batch of single-use nonces for the epoch, keeps the
+// secret halves and returns the commitments to the signature aggregator.
+func (s *server) Preprocess(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var preprocess PreprocessRequest
+	if err := json.Unmarshal(*params, &preprocess); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(preprocess); err != nil {
+		return nil, err
+	}
+
+	if preprocess.Count > MaxPreprocessBatch {
+		return nil, fmt.Errorf("cannot preprocess more than %d nonces at once", MaxPreprocessBatch)
+	}
+
+	key, err := s.store.GetKeyPackage(preprocess.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	cs, err := frost.CiphersuiteByID(key.Ciphersuite)
+	if err != nil {
+		return nil, err
+	}
+
+	next, err := s.store.NextNonceIndex(preprocess.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	nonces, commitments, err := frost.Preprocess(cs, next, preprocess.Count, key.SigningShare)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.PutNonces(preprocess.Epoch, nonces); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(PreprocessResponse{
+		Commitments: commitments,
+	})
+}
+
+// Sign computes this party's signature share for the signing package. The
+// nonce it commits to is deleted before signing, so it can never be reused.
+func (s *server) Sign(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var sign SignRequest
+	if err := json.Unmarshal(*params, &sign); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(sign); err != nil {
+		return nil, err
+	}
+
+	key, err := s.store.GetKeyPackage(sign.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	cs, err := frost.CiphersuiteByID(key.Ciphersuite)
+	if err != nil {
+		return nil, err
+	}
+
+	if uint(len(sign.Package.Commitments)) < key.Threshold {
+		return nil, fmt.Errorf("signing package has %d signers, threshold is %d", len(sign.Package.Commitments), key.Threshold)
+	}
+
+	own, ok := sign.Package.Commitment(key.Identifier)
+	if !ok {
+		return nil, fmt.Errorf("party %s is not part of the signing package", s.id)
+	}
+
+	nonce, err := s.store.TakeNonce(sign.Epoch, own.Commitment.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	share, err := frost.Sign(cs, frost.KeyShare{
+		Identifier:     key.Identifier,
+		SigningShare:   key.SigningShare,
+		GroupPublicKey: key.GroupPublicKey,
+	}, nonce, sign.Package)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(SignResponse{
+		Share: share,
+	})
+}
+
+// sync delivers the messages waiting for this party.
+func (s *server) sync() error {
+	if err := s.transport.Sync(s.deliver); err != nil {
+		return fmt.Errorf("failed to sync inbox: %w", err)
+	}
+	return nil
+}
+
+// deliver hands a message that arrived through the transport to the rpc
+// another party would have called directly. Only the peer to peer rpcs can
+// be reached this way, and the message must come from the party it claims
+// to be from.
+func (s *server) deliver(sender, method string, params json.RawMessage) error {
+	var (
+		from    string
+		handler func(context.Context, *json.RawMessage) (json.RawMessage, error)
+	)
+
+	switch method {
+	case "dkg_round1_package":
+		var request DKGRound1PackageRequest
+		if err := json.Unmarshal(params, &request); err != nil {
+			return err
+		}
+		from, handler = request.Package.Sender, s.DkgRound1Package
+
+	case "dkg_round2_share":
+		var request DKGRound2ShareRequest
+		if err := json.Unmarshal(params, &request); err != nil {
+			return err
+		}
+		from, handler = request.Share.Sender, s.DkgRound2Share
+
+	case "repair_delta":
+		var request RepairDeltaRequest
+		if err := json.Unmarshal(params, &request); err != nil {
+			return err
+		}
+		from, handler = request.Delta.Sender, s.RepairDelta
+
+	case "repair_sigma":
+		var request RepairSigmaRequest
+		if err := json.Unmarshal(params, &request); err != nil {
+			return err
+		}
+		from, handler = request.Sigma.Sender, s.RepairSigma
+
+	default:
+		return fmt.Errorf("%s cannot be delivered to a party", method)
+	}
+
+	if from != sender {
+		return fmt.Errorf("%s from %s claims to be from %s", method, sender, from)
+	}
+
+	_, err := handler(context.Background(), &params)
+	return err
+}
+
+// retry calls fn up to `attempts` times, doubling the wait between failures.
+func retry(attempts int, backoff time.Duration, fn func() error) error {
+	var err error
+	for i := 0; i < attempts; i++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		if i < attempts-1 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	return err
+}
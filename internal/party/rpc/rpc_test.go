@@ -0,0 +1,170 @@
+package rpc_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"frost/internal/party/dkg"
+	"frost/internal/party/identity"
+	"frost/internal/party/partyclient"
+	"frost/internal/party/rpc"
+	"frost/internal/party/store"
+	sigagrpc "frost/internal/sigag/rpc"
+	"frost/pkg/frost"
+	"frost/pkg/types"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rosedblabs/rosedb/v2"
+	"github.com/sirupsen/logrus"
+)
+
+var _ = Describe("Party server", func() {
+	cs := frost.Secp256k1SHA256
+
+	var (
+		client   partyclient.PartyClient
+		keys     map[string]*identity.Key
+		parties  sigagrpc.Parties
+		peers    map[string]*dkg.Participant
+		received map[string][]types.JSONRequest
+	)
+
+	BeforeEach(func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).To(BeNil())
+		port := listener.Addr().(*net.TCPAddr).Port
+		Expect(listener.Close()).To(Succeed())
+
+		dir, err := os.MkdirTemp("", "party_rpc")
+		Expect(err).To(BeNil())
+		options := rosedb.DefaultOptions
+		options.DirPath = dir
+		db, err := rosedb.Open(options)
+		Expect(err).To(BeNil())
+		DeferCleanup(func() {
+			_ = db.Close()
+			_ = os.RemoveAll(dir)
+		})
+
+		s := store.New(db)
+		key, err := s.Identity()
+		Expect(err).To(BeNil())
+
+		logger := logrus.New()
+		logger.SetOutput(io.Discard)
+		gin.SetMode(gin.TestMode)
+
+		dial := rpc.PeerDialer(func(id, url string) rpc.Peer {
+			return partyclient.NewFromURL(id, url)
+		})
+		go func() {
+			_ = rpc.NewServer("8801", key, s, logger, nil, dial).Run(fmt.Sprint(port))
+		}()
+
+		client = partyclient.New("8801", fmt.Sprintf("127.0.0.1:%d", port), true)
+		Eventually(client.Ping).Should(Succeed())
+
+		keys = map[string]*identity.Key{"8801": key}
+		parties = sigagrpc.Parties{"8801": fmt.Sprintf("http://127.0.0.1:%d/", port)}
+		identityKeys := sigagrpc.IdentityKeys{"8801": key.PublicKey()}
+		signingKeys := map[string]string{"8801": key.SigningKey()}
+		received = make(map[string][]types.JSONRequest)
+		var mu sync.Mutex
+		for _, id := range []string{"8802", "8803"} {
+			id := id
+			keys[id], err = identity.Generate()
+			Expect(err).To(BeNil())
+
+			// the peer records what the party sends it directly
+			peer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var request types.JSONRequest
+				_ = json.NewDecoder(r.Body).Decode(&request)
+				mu.Lock()
+				received[id] = append(received[id], request)
+				mu.Unlock()
+				_ = json.NewEncoder(w).Encode(types.JSONResponse{JSONRPC: types.Version, Result: json.RawMessage("true"), ID: request.ID})
+			}))
+			DeferCleanup(peer.Close)
+
+			parties[id] = peer.URL + "/"
+			identityKeys[id] = keys[id].PublicKey()
+			signingKeys[id] = keys[id].SigningKey()
+		}
+
+		identifiers, err := dkg.Identifiers(cs.Group(), parties)
+		Expect(err).To(BeNil())
+
+		Expect(client.NewEpoch(1)).To(Succeed())
+		Expect(client.DKGInit(rpc.DKGInitRequest{
+			Epoch:        1,
+			Ciphersuite:  cs.ID(),
+			Parties:      parties,
+			Threshold:    2,
+			IdentityKeys: identityKeys,
+			SigningKeys:  signingKeys,
+			Identifiers:  identifiers,
+		})).To(Succeed())
+
+		peers = make(map[string]*dkg.Participant)
+		for _, id := range []string{"8802", "8803"} {
+			peers[id], err = dkg.NewParticipant(1, cs, id, parties, 2)
+			Expect(err).To(BeNil())
+			Expect(peers[id].SetIdentityKeys(identityKeys, keys[id].PublicKey())).To(Succeed())
+		}
+	})
+
+	// round1 is the round 1 package of `id`, signed with `signer`'s key.
+	round1 := func(id string, signer string) dkg.Round1Package {
+		pkg, err := peers[id].DkGRound1()
+		Expect(err).To(BeNil())
+		pkg.Signature = keys[signer].Sign(pkg.SignedBytes())
+		return pkg
+	}
+
+	It("should take the round 1 packages peers send directly only when their sender signed them", func() {
+		Expect(client.DKGRound1Package(round1("8803", "8802"))).ToNot(Succeed())
+		Expect(client.DKGRound1Package(round1("8803", "8803"))).To(Succeed())
+
+		deliveries, err := client.DKGDeliveries(1)
+		Expect(err).To(BeNil())
+		Expect(deliveries.Round1).To(ConsistOf("8802"))
+	})
+
+	It("should send its round 1 package to its peers directly and take only round 2 shares their sender sealed", func() {
+		own, err := client.DKGRound1(1, nil)
+		Expect(err).To(BeNil())
+		for _, id := range []string{"8802", "8803"} {
+			Expect(received[id]).To(HaveLen(1))
+			Expect(received[id][0].Method).To(Equal("dkg_round1_package"))
+		}
+		packages := map[string]dkg.Round1Package{"8801": *own}
+		for _, id := range []string{"8802", "8803"} {
+			packages[id] = round1(id, id)
+			Expect(client.DKGRound1Package(packages[id])).To(Succeed())
+		}
+
+		shares, err := peers["8802"].DkGRound2(packages)
+		Expect(err).To(BeNil())
+		var share dkg.Round2Share
+		for _, s := range shares {
+			if s.Recipient == "8801" {
+				share = s
+			}
+		}
+
+		forged, err := peers["8802"].SealShare(keys["8803"], share)
+		Expect(err).To(BeNil())
+		Expect(client.DKGRound2Share(forged)).ToNot(Succeed())
+
+		sealed, err := peers["8802"].SealShare(keys["8802"], share)
+		Expect(err).To(BeNil())
+		Expect(client.DKGRound2Share(sealed)).To(Succeed())
+	})
+})
@@ -1,25 +1,67 @@
 package store
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"frost/internal/party/dkg"
+	"frost/internal/party/identity"
 	"frost/internal/party/rpc"
+	"frost/pkg/frost"
+	"regexp"
 	"sync"
+
+	"github.com/rosedblabs/rosedb/v2"
 )
 
+// participantKey matches the keys the participants of every epoch are kept
+// under.
+var participantKey = regexp.MustCompile(`^EPOCH_\d+_PARTICIPANT$`)
+
+// store keeps a party's key material in rosedb, so it survives a restart. The
+// writes of a protocol step go to disk in a single synced batch, a crash
+// leaves either all of them or none.
 type store struct {
-	mu           sync.RWMutex
-	locked       bool
-	currentEpoch uint
+	mu sync.RWMutex
+	db *rosedb.DB
 }
 
 type Store interface {
 	rpc.Store
+
+	Identity() (*identity.Key, error)
 }
 
-func New() Store {
+func New(db *rosedb.DB) Store {
 	return &store{
 		mu: sync.RWMutex{},
+		db: db,
+	}
+}
+
+// Identity returns the party's identity key, which is generated and kept on
+// the first start. Peers seal their shares and mailbox messages to it, and
+// sigag only takes another key from the party once it is repaired, so it must
+// not change while the database lasts.
+func (s *store) Identity() (*identity.Key, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := &identity.Key{}
+	found, err := s.get("IDENTITY", key)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		return key, nil
+	}
+
+	key, err = identity.Generate()
+	if err != nil {
+		return nil, err
 	}
+
+	return key, s.write(map[string]interface{}{"IDENTITY": key})
 }
 
 // IsLocked implements Store.
@@ -27,31 +69,575 @@ func (s *store) IsLocked() bool {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	return s.locked
+	var locked bool
+	_, _ = s.get("LOCKED", &locked)
+	return locked
 }
 
 // Lock implements Store.
-func (s *store) Lock() {
+func (s *store) Lock() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.locked = true
+	return s.write(map[string]interface{}{"LOCKED": true})
 }
 
-// UnLock implements Store.
-func (s *store) UnLock() {
+func (s *store) NewEpoch(epoch uint) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.locked = false
+	var locked bool
+	if _, err := s.get("LOCKED", &locked); err != nil {
+		return err
+	}
+
+	if epoch <= s.currentEpoch() && !locked {
+		return fmt.Errorf("recevied invalid epoch %d", epoch)
+	}
+
+	return s.write(map[string]interface{}{"CURRENT_EPOCH": epoch})
 }
 
-func (s *store) NewEpoch(epoch uint) error {
+// CurrentEpoch implements Store.
+func (s *store) CurrentEpoch() uint {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.currentEpoch()
+}
+
+func (s *store) currentEpoch() uint {
+	var epoch uint
+	_, _ = s.get("CURRENT_EPOCH", &epoch)
+	return epoch
+}
+
+// PutParticipant implements Store. The party's own round 1 package, nil for
+// a new member of a resharing, is kept with it.
+func (s *store) PutParticipant(participant *dkg.Participant, pkg *dkg.Round1Package) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if epoch <= s.currentEpoch && !s.locked {
-		return fmt.Errorf("recevied invalid epoch %s", epoch)
+
+	found, err := s.get(participantKeyOf(participant.Epoch), nil)
+	if err != nil {
+		return err
+	}
+	if found {
+		return fmt.Errorf("dkg already initiated for epoch %d", participant.Epoch)
+	}
+
+	writes := map[string]interface{}{participantKeyOf(participant.Epoch): participant}
+	if pkg != nil {
+		packages, err := s.addRound1Package(*pkg)
+		if err != nil {
+			return err
+		}
+		writes[fmt.Sprintf("EPOCH_%d_ROUND1_PACKAGES", pkg.Epoch)] = packages
+	}
+
+	return s.write(writes)
+}
+
+// UpdateParticipant implements Store.
+func (s *store) UpdateParticipant(participant *dkg.Participant) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	found, err := s.get(participantKeyOf(participant.Epoch), nil)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("dkg not initiated for epoch %d", participant.Epoch)
+	}
+
+	return s.write(map[string]interface{}{participantKeyOf(participant.Epoch): participant})
+}
+
+// GetParticipant implements Store.
+func (s *store) GetParticipant(epoch uint) (*dkg.Participant, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	participant := &dkg.Participant{}
+	found, err := s.get(participantKeyOf(epoch), participant)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("dkg not initiated for epoch %d", epoch)
+	}
+
+	return participant, nil
+}
+
+func participantKeyOf(epoch uint) string {
+	return fmt.Sprintf("EPOCH_%d_PARTICIPANT", epoch)
+}
+
+// PutRound1Package implements Store.
+func (s *store) PutRound1Package(pkg dkg.Round1Package) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	packages, err := s.addRound1Package(pkg)
+	if err != nil {
+		return err
+	}
+
+	return s.write(map[string]interface{}{fmt.Sprintf("EPOCH_%d_ROUND1_PACKAGES", pkg.Epoch): packages})
+}
+
+// addRound1Package returns the round 1 packages of the epoch with pkg added.
+func (s *store) addRound1Package(pkg dkg.Round1Package) (map[string]dkg.Round1Package, error) {
+	packages := make(map[string]dkg.Round1Package)
+	if _, err := s.get(fmt.Sprintf("EPOCH_%d_ROUND1_PACKAGES", pkg.Epoch), &packages); err != nil {
+		return nil, err
+	}
+
+	if existing, ok := packages[pkg.Sender]; ok {
+		if existing.Equal(pkg) {
+			return packages, nil
+		}
+		return nil, fmt.Errorf("conflicting round 1 package from %s", pkg.Sender)
 	}
-	s.currentEpoch = epoch
+
+	packages[pkg.Sender] = pkg
+	return packages, nil
+}
+
+// GetRound1Packages implements Store.
+func (s *store) GetRound1Packages(epoch uint) (map[string]dkg.Round1Package, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	packages := make(map[string]dkg.Round1Package)
+	if _, err := s.get(fmt.Sprintf("EPOCH_%d_ROUND1_PACKAGES", epoch), &packages); err != nil {
+		return nil, err
+	}
+	return packages, nil
+}
+
+// PutRound2Share implements Store.
+func (s *store) PutRound2Share(share dkg.Round2Share) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := fmt.Sprintf("EPOCH_%d_ROUND2_SHARES", share.Epoch)
+	shares := make(map[string]dkg.Round2Share)
+	if _, err := s.get(key, &shares); err != nil {
+		return err
+	}
+
+	if existing, ok := shares[share.Sender]; ok {
+		if existing.Value.Equal(share.Value) {
+			return nil
+		}
+		return fmt.Errorf("conflicting round 2 share from %s", share.Sender)
+	}
+
+	shares[share.Sender] = share
+	return s.write(map[string]interface{}{key: shares})
+}
+
+// GetRound2Shares implements Store.
+func (s *store) GetRound2Shares(epoch uint) (map[string]dkg.Round2Share, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	shares := make(map[string]dkg.Round2Share)
+	if _, err := s.get(fmt.Sprintf("EPOCH_%d_ROUND2_SHARES", epoch), &shares); err != nil {
+		return nil, err
+	}
+	return shares, nil
+}
+
+// FinishDKG implements Store. The key package, the participant as it
+// finished and the unlock are written together, so a crash never leaves a
+// party locked without its key or holding half of it.
+func (s *store) FinishDKG(participant *dkg.Participant, key *dkg.KeyPackage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkNoKeyPackage(key.Epoch); err != nil {
+		return err
+	}
+
+	return s.write(map[string]interface{}{
+		participantKeyOf(participant.Epoch): participant,
+		keyPackageKeyOf(key.Epoch):          key,
+		"LOCKED":                            false,
+	})
+}
+
+// PutKeyPackage implements Store.
+func (s *store) PutKeyPackage(key *dkg.KeyPackage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkNoKeyPackage(key.Epoch); err != nil {
+		return err
+	}
+
+	return s.write(map[string]interface{}{keyPackageKeyOf(key.Epoch): key})
+}
+
+// GetKeyPackage implements Store.
+func (s *store) GetKeyPackage(epoch uint) (*dkg.KeyPackage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key := &dkg.KeyPackage{}
+	found, err := s.get(keyPackageKeyOf(epoch), key)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("no key package for epoch %d", epoch)
+	}
+
+	return key, nil
+}
+
+func (s *store) checkNoKeyPackage(epoch uint) error {
+	found, err := s.get(keyPackageKeyOf(epoch), nil)
+	if err != nil {
+		return err
+	}
+	if found {
+		return fmt.Errorf("key package for epoch %d already exists", epoch)
+	}
+
 	return nil
 }
+
+func keyPackageKeyOf(epoch uint) string {
+	return fmt.Sprintf("EPOCH_%d_KEY_PACKAGE", epoch)
+}
+
+// RetireEpoch implements Store. It drops the epoch's key package, nonces,
+// DKG and repair state, including references held by the participant that
+// refreshed it. rosedb only reclaims the space of dropped values when its
+// log is merged.
+func (s *store) RetireEpoch(epoch uint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	found, err := s.get(keyPackageKeyOf(epoch), nil)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("no key package for epoch %d", epoch)
+	}
+
+	var keys []string
+	s.db.AscendKeys([]byte(participantKey.String()), false, func(k []byte) (bool, error) {
+		keys = append(keys, string(k))
+		return true, nil
+	})
+
+	writes := make(map[string]interface{})
+	for _, key := range keys {
+		participant := &dkg.Participant{}
+		if _, err := s.get(key, participant); err != nil {
+			return err
+		}
+
+		if participant.Previous != nil && participant.Previous.Epoch == epoch {
+			participant.Previous = nil
+			writes[key] = participant
+		}
+	}
+
+	return s.write(writes,
+		keyPackageKeyOf(epoch),
+		fmt.Sprintf("EPOCH_%d_NONCES", epoch),
+		participantKeyOf(epoch),
+		fmt.Sprintf("EPOCH_%d_ROUND1_PACKAGES", epoch),
+		fmt.Sprintf("EPOCH_%d_ROUND2_SHARES", epoch),
+		fmt.Sprintf("EPOCH_%d_RECOVERY", epoch),
+		fmt.Sprintf("EPOCH_%d_REPAIR_SIGMAS", epoch),
+		fmt.Sprintf("EPOCH_%d_REPAIR_DELTAS", epoch),
+	)
+}
+
+// NextNonceIndex implements Store.
+func (s *store) NextNonceIndex(epoch uint) (uint, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.nextNonceIndex(epoch)
+}
+
+func (s *store) nextNonceIndex(epoch uint) (uint, error) {
+	var next uint
+	if _, err := s.get(fmt.Sprintf("EPOCH_%d_NEXT_NONCE", epoch), &next); err != nil {
+		return 0, err
+	}
+	return next, nil
+}
+
+// PutNonces implements Store. Nonce indices are never reused within an epoch.
+func (s *store) PutNonces(epoch uint, nonces []*frost.Nonce) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next, err := s.nextNonceIndex(epoch)
+	if err != nil {
+		return err
+	}
+	for _, nonce := range nonces {
+		if nonce.Index < next {
+			return fmt.Errorf("nonce index %d already used in epoch %d", nonce.Index, epoch)
+		}
+		next = nonce.Index + 1
+	}
+
+	pool, err := s.noncePool(epoch)
+	if err != nil {
+		return err
+	}
+
+	for _, nonce := range nonces {
+		pool[nonce.Index] = nonce
+	}
+
+	return s.write(map[string]interface{}{
+		fmt.Sprintf("EPOCH_%d_NONCES", epoch):     pool,
+		fmt.Sprintf("EPOCH_%d_NEXT_NONCE", epoch): next,
+	})
+}
+
+// TakeNonce implements Store. The nonce is removed from the pool so that it can
+// only ever be used for a single signature.
+func (s *store) TakeNonce(epoch uint, index uint) (*frost.Nonce, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pool, err := s.noncePool(epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, ok := pool[index]
+	if !ok {
+		return nil, fmt.Errorf("nonce %d not found for epoch %d", index, epoch)
+	}
+
+	delete(pool, index)
+	if err := s.write(map[string]interface{}{fmt.Sprintf("EPOCH_%d_NONCES", epoch): pool}); err != nil {
+		return nil, err
+	}
+
+	return nonce, nil
+}
+
+func (s *store) noncePool(epoch uint) (map[uint]*frost.Nonce, error) {
+	pool := make(map[uint]*frost.Nonce)
+	if _, err := s.get(fmt.Sprintf("EPOCH_%d_NONCES", epoch), &pool); err != nil {
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+// ResumeNonces implements Store. Nonce indices below next are never handed
+// out, so a repaired party does not reuse indices sigag already holds.
+func (s *store) ResumeNonces(epoch uint, next uint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.nextNonceIndex(epoch)
+	if err != nil {
+		return err
+	}
+	if next <= current {
+		return nil
+	}
+
+	return s.write(map[string]interface{}{fmt.Sprintf("EPOCH_%d_NEXT_NONCE", epoch): next})
+}
+
+// PutRecovery implements Store.
+func (s *store) PutRecovery(recovery *dkg.Recovery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkNoKeyPackage(recovery.Epoch); err != nil {
+		return err
+	}
+
+	found, err := s.get(fmt.Sprintf("EPOCH_%d_RECOVERY", recovery.Epoch), nil)
+	if err != nil {
+		return err
+	}
+	if found {
+		return fmt.Errorf("repair already started for epoch %d", recovery.Epoch)
+	}
+
+	return s.write(map[string]interface{}{fmt.Sprintf("EPOCH_%d_RECOVERY", recovery.Epoch): recovery})
+}
+
+// GetRecovery implements Store.
+func (s *store) GetRecovery(epoch uint) (*dkg.Recovery, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	recovery := &dkg.Recovery{}
+	found, err := s.get(fmt.Sprintf("EPOCH_%d_RECOVERY", epoch), recovery)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("no repair started for epoch %d", epoch)
+	}
+
+	return recovery, nil
+}
+
+// FinishRepair implements Store. The rebuilt key package replaces the
+// repair state in a single write.
+func (s *store) FinishRepair(key *dkg.KeyPackage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkNoKeyPackage(key.Epoch); err != nil {
+		return err
+	}
+
+	return s.write(map[string]interface{}{keyPackageKeyOf(key.Epoch): key},
+		fmt.Sprintf("EPOCH_%d_RECOVERY", key.Epoch),
+		fmt.Sprintf("EPOCH_%d_REPAIR_SIGMAS", key.Epoch),
+	)
+}
+
+// PutRepairSigma implements Store.
+func (s *store) PutRepairSigma(sigma dkg.RepairSigma) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := fmt.Sprintf("EPOCH_%d_REPAIR_SIGMAS", sigma.Epoch)
+	sigmas := make(map[string]dkg.RepairSigma)
+	if _, err := s.get(key, &sigmas); err != nil {
+		return err
+	}
+
+	if existing, ok := sigmas[sigma.Sender]; ok {
+		if existing.Value.Equal(sigma.Value) {
+			return nil
+		}
+		return fmt.Errorf("conflicting repair sigma from %s", sigma.Sender)
+	}
+
+	sigmas[sigma.Sender] = sigma
+	return s.write(map[string]interface{}{key: sigmas})
+}
+
+// GetRepairSigmas implements Store.
+func (s *store) GetRepairSigmas(epoch uint) (map[string]dkg.RepairSigma, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sigmas := make(map[string]dkg.RepairSigma)
+	if _, err := s.get(fmt.Sprintf("EPOCH_%d_REPAIR_SIGMAS", epoch), &sigmas); err != nil {
+		return nil, err
+	}
+	return sigmas, nil
+}
+
+// PutRepairDelta implements Store.
+func (s *store) PutRepairDelta(delta dkg.RepairDelta) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := fmt.Sprintf("EPOCH_%d_REPAIR_DELTAS", delta.Epoch)
+	repairs := make(map[string]map[string]dkg.RepairDelta)
+	if _, err := s.get(key, &repairs); err != nil {
+		return err
+	}
+
+	deltas, ok := repairs[delta.Lost]
+	if !ok {
+		deltas = make(map[string]dkg.RepairDelta)
+		repairs[delta.Lost] = deltas
+	}
+
+	if existing, ok := deltas[delta.Sender]; ok {
+		if existing.Value.Equal(delta.Value) {
+			return nil
+		}
+		return fmt.Errorf("conflicting repair delta from %s", delta.Sender)
+	}
+
+	deltas[delta.Sender] = delta
+	return s.write(map[string]interface{}{key: repairs})
+}
+
+// TakeRepairDeltas implements Store. The deltas are removed, so a helper only
+// ever sends a single σ per repair.
+func (s *store) TakeRepairDeltas(epoch uint, lost string) (map[string]dkg.RepairDelta, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := fmt.Sprintf("EPOCH_%d_REPAIR_DELTAS", epoch)
+	repairs := make(map[string]map[string]dkg.RepairDelta)
+	if _, err := s.get(key, &repairs); err != nil {
+		return nil, err
+	}
+
+	deltas := repairs[lost]
+	delete(repairs, lost)
+	if err := s.write(map[string]interface{}{key: repairs}); err != nil {
+		return nil, err
+	}
+
+	return deltas, nil
+}
+
+// get decodes the json value stored under key into value, unless value is
+// nil, and reports whether there is one.
+func (s *store) get(key string, value interface{}) (bool, error) {
+	data, err := s.db.Get([]byte(key))
+	if errors.Is(err, rosedb.ErrKeyNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if value == nil {
+		return true, nil
+	}
+
+	return true, json.Unmarshal(data, value)
+}
+
+// write stores the json encoding of every value and deletes the keys in a
+// single batch.
+func (s *store) write(values map[string]interface{}, deletes ...string) error {
+	encoded := make(map[string][]byte, len(values))
+	for key, value := range values {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		encoded[key] = data
+	}
+
+	batch := s.db.NewBatch(rosedb.DefaultBatchOptions)
+	for key, data := range encoded {
+		if err := batch.Put([]byte(key), data); err != nil {
+			_ = batch.Rollback()
+			return err
+		}
+	}
+	for _, key := range deletes {
+		if err := batch.Delete([]byte(key)); err != nil {
+			_ = batch.Rollback()
+			return err
+		}
+	}
+
+	return batch.Commit()
+}
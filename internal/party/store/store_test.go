@@ -0,0 +1,227 @@
+package store_test
+
+import (
+	"frost/internal/party/dkg"
+	"frost/internal/party/store"
+	sigagrpc "frost/internal/sigag/rpc"
+	"frost/pkg/frost"
+	"frost/pkg/group"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rosedblabs/rosedb/v2"
+)
+
+var parties = sigagrpc.Parties{
+	"8801": "127.0.0.1:8801/",
+	"8802": "127.0.0.1:8802/",
+	"8803": "127.0.0.1:8803/",
+}
+
+var _ = Describe("Store", func() {
+	cs := frost.Secp256k1SHA256
+
+	var (
+		dir string
+		db  *rosedb.DB
+		s   store.Store
+
+		participant *dkg.Participant
+		packages    map[string]dkg.Round1Package
+		shares      map[string]dkg.Round2Share
+	)
+
+	// restart opens the database again, as a restarted party would.
+	restart := func() {
+		if db != nil {
+			Expect(db.Close()).To(Succeed())
+		}
+
+		options := rosedb.DefaultOptions
+		options.DirPath = dir
+		var err error
+		db, err = rosedb.Open(options)
+		Expect(err).To(BeNil())
+
+		s = store.New(db)
+	}
+
+	// finishDKG stores the dkg of 8801 up to its key package.
+	finishDKG := func() *dkg.KeyPackage {
+		Expect(s.Lock()).To(Succeed())
+		Expect(s.NewEpoch(1)).To(Succeed())
+		Expect(s.PutParticipant(participant, nil)).To(Succeed())
+		for _, pkg := range packages {
+			Expect(s.PutRound1Package(pkg)).To(Succeed())
+		}
+		for _, share := range shares {
+			Expect(s.PutRound2Share(share)).To(Succeed())
+		}
+
+		key, err := participant.Finalize(packages, shares)
+		Expect(err).To(BeNil())
+		Expect(s.FinishDKG(participant, key)).To(Succeed())
+		return key
+	}
+
+	nonces := func(indices ...uint) []*frost.Nonce {
+		var nonces []*frost.Nonce
+		for _, index := range indices {
+			nonce, err := frost.NewNonce(cs, index, group.ScalarFromInt(cs.Group(), 1))
+			Expect(err).To(BeNil())
+			nonces = append(nonces, nonce)
+		}
+		return nonces
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "party_store")
+		Expect(err).To(BeNil())
+
+		db = nil
+		restart()
+
+		participants := make(map[string]*dkg.Participant)
+		packages = make(map[string]dkg.Round1Package)
+		for id := range parties {
+			participants[id], err = dkg.NewParticipant(1, cs, id, parties, 2)
+			Expect(err).To(BeNil())
+
+			packages[id], err = participants[id].DkGRound1()
+			Expect(err).To(BeNil())
+		}
+		participant = participants["8801"]
+
+		shares = make(map[string]dkg.Round2Share)
+		for id, dealer := range participants {
+			dealt, err := dealer.DkGRound2(packages)
+			Expect(err).To(BeNil())
+			for _, share := range dealt {
+				if share.Recipient == "8801" {
+					shares[id] = share
+				}
+			}
+		}
+	})
+
+	AfterEach(func() {
+		_ = db.Close()
+		_ = os.RemoveAll(dir)
+	})
+
+	It("should keep the identity key across restarts", func() {
+		key, err := s.Identity()
+		Expect(err).To(BeNil())
+
+		restart()
+		restored, err := s.Identity()
+		Expect(err).To(BeNil())
+		Expect(restored.PublicKey()).To(Equal(key.PublicKey()))
+		Expect(restored.SigningKey()).To(Equal(key.SigningKey()))
+	})
+
+	It("should write the key package and the unlock together", func() {
+		Expect(s.Lock()).To(Succeed())
+		Expect(s.IsLocked()).To(BeTrue())
+
+		key := finishDKG()
+		Expect(s.IsLocked()).To(BeFalse())
+
+		restart()
+		Expect(s.IsLocked()).To(BeFalse())
+		stored, err := s.GetKeyPackage(1)
+		Expect(err).To(BeNil())
+		Expect(stored.SigningShare.Equal(key.SigningShare)).To(BeTrue())
+		Expect(stored.GroupPublicKey.Equal(key.GroupPublicKey)).To(BeTrue())
+
+		// a refused finish leaves the party locked rather than half done
+		Expect(s.Lock()).To(Succeed())
+		Expect(s.FinishDKG(participant, key)).ToNot(Succeed())
+		Expect(s.IsLocked()).To(BeTrue())
+	})
+
+	It("should refuse a conflicting round 1 package or round 2 share", func() {
+		Expect(s.PutParticipant(participant, nil)).To(Succeed())
+		Expect(s.PutRound1Package(packages["8802"])).To(Succeed())
+		Expect(s.PutRound2Share(shares["8802"])).To(Succeed())
+
+		// the same message delivered twice is fine
+		Expect(s.PutRound1Package(packages["8802"])).To(Succeed())
+		Expect(s.PutRound2Share(shares["8802"])).To(Succeed())
+
+		forged := packages["8803"]
+		forged.Sender = "8802"
+		Expect(s.PutRound1Package(forged)).ToNot(Succeed())
+
+		share := shares["8802"]
+		share.Value = share.Value.Add(group.ScalarFromInt(cs.Group(), 1))
+		Expect(s.PutRound2Share(share)).ToNot(Succeed())
+
+		restart()
+		stored, err := s.GetRound1Packages(1)
+		Expect(err).To(BeNil())
+		Expect(stored["8802"].Equal(packages["8802"])).To(BeTrue())
+
+		storedShares, err := s.GetRound2Shares(1)
+		Expect(err).To(BeNil())
+		Expect(storedShares["8802"].Value.Equal(shares["8802"].Value)).To(BeTrue())
+	})
+
+	It("should never hand out a nonce index twice, even after a restart", func() {
+		Expect(s.PutNonces(1, nonces(0, 1, 2))).To(Succeed())
+
+		nonce, err := s.TakeNonce(1, 1)
+		Expect(err).To(BeNil())
+		Expect(nonce.Index).To(Equal(uint(1)))
+		_, err = s.TakeNonce(1, 1)
+		Expect(err).ToNot(BeNil())
+
+		restart()
+		next, err := s.NextNonceIndex(1)
+		Expect(err).To(BeNil())
+		Expect(next).To(Equal(uint(3)))
+
+		_, err = s.TakeNonce(1, 1)
+		Expect(err).ToNot(BeNil())
+		Expect(s.PutNonces(1, nonces(2, 3))).ToNot(Succeed())
+
+		_, err = s.TakeNonce(1, 0)
+		Expect(err).To(BeNil())
+
+		Expect(s.PutNonces(1, nonces(3, 4))).To(Succeed())
+		Expect(s.ResumeNonces(1, 2)).To(Succeed())
+		next, err = s.NextNonceIndex(1)
+		Expect(err).To(BeNil())
+		Expect(next).To(Equal(uint(5)))
+	})
+
+	It("should delete the secrets of a retired epoch", func() {
+		finishDKG()
+		Expect(s.PutNonces(1, nonces(0, 1))).To(Succeed())
+
+		Expect(s.RetireEpoch(1)).To(Succeed())
+
+		restart()
+		_, err := s.GetKeyPackage(1)
+		Expect(err).ToNot(BeNil())
+		_, err = s.GetParticipant(1)
+		Expect(err).ToNot(BeNil())
+		_, err = s.TakeNonce(1, 0)
+		Expect(err).ToNot(BeNil())
+
+		stored, err := s.GetRound1Packages(1)
+		Expect(err).To(BeNil())
+		Expect(stored).To(BeEmpty())
+
+		storedShares, err := s.GetRound2Shares(1)
+		Expect(err).To(BeNil())
+		Expect(storedShares).To(BeEmpty())
+
+		// the nonce index outlives the epoch's nonces
+		next, err := s.NextNonceIndex(1)
+		Expect(err).To(BeNil())
+		Expect(next).To(Equal(uint(2)))
+	})
+})
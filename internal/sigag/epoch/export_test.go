@@ -0,0 +1,33 @@
+package epoch
+
+import (
+	"frost/internal/party/dkg"
+	"frost/internal/party/partyclient"
+	partyrpc "frost/internal/party/rpc"
+	"frost/internal/sigag/rpc"
+	"frost/pkg/collections"
+	"time"
+)
+
+// Recover and Step drive the runner's state machine one move at a time.
+func Recover(r Runner) (State, error) {
+	return r.(*runner).recover()
+}
+
+func Step(r Runner, state State, epochDuration time.Duration) (State, error) {
+	return r.(*runner).step(state, &dkgRun{}, epochDuration)
+}
+
+// PlanDKG plans the dkg of an epoch run by the parties of `partyMap`.
+func PlanDKG(r Runner, partyMap rpc.Parties, epoch uint) (partyrpc.DKGInitRequest, error) {
+	return r.(*runner).planDKG(partyMap, epoch)
+}
+
+// CheckRound1Broadcast and AnnounceDKGFinalize run a single step of the dkg.
+func CheckRound1Broadcast(r Runner, parties *collections.OrderedList[partyclient.PartyClient], dkgInit partyrpc.DKGInitRequest, packages map[string]dkg.Round1Package, disqualified map[string]string) {
+	r.(*runner).CheckRound1Broadcast(parties, dkgInit, packages, disqualified)
+}
+
+func AnnounceDKGFinalize(r Runner, parties *collections.OrderedList[partyclient.PartyClient], dkgInit partyrpc.DKGInitRequest, packages map[string]dkg.Round1Package, disqualified map[string]string) (rpc.GroupKey, rpc.VerificationShares, error) {
+	return r.(*runner).AnnounceDKGFinalize(parties, dkgInit, packages, disqualified)
+}
@@ -1,9 +1,18 @@
 package epoch
 
 import (
+	"encoding/hex"
+	"fmt"
+	"frost/internal/party/dkg"
+	"frost/internal/party/identity"
 	"frost/internal/party/partyclient"
+	partyrpc "frost/internal/party/rpc"
 	"frost/internal/sigag/rpc"
+	sss "frost/pkg/SSS"
 	"frost/pkg/collections"
+	"frost/pkg/frost"
+	"frost/pkg/group"
+	"sort"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -13,101 +22,477 @@ type Runner interface {
 	Run(time.Duration) error
 }
 
+// NoncePool configures how many nonce commitments sigag keeps per party.
+type NoncePool struct {
+	Size         uint
+	LowWaterMark uint
+}
+
+// replenishInterval is how often nonce pools are checked against the low-water mark.
+const replenishInterval = 5 * time.Second
+
 type runner struct {
-	nextepoch uint
-	initTick  time.Duration
-	logger    *logrus.Logger
+	initTick time.Duration
+	logger   *logrus.Logger
 
 	store           Store
 	thresholdFactor float64
+	noncePool       NoncePool
+	ciphersuite     frost.Ciphersuite
+	epochMode       string
 }
 
 type Store interface {
-	Lock()
-	UnLock()
+	PutEpochState(state State) error
+	GetEpochState() (State, error)
+	RestoreParties() error
 
-	PutParties(parties rpc.Parties) error
+	PutParties(parties rpc.Parties, epoch uint) error
 	GetPartyCLients() *collections.OrderedList[partyclient.PartyClient]
 	RemoveParty(item partyclient.PartyClient) error
 
 	PutThreshold(threshold uint, epoch uint) error
+	PutCiphersuite(ciphersuite string, epoch uint) error
+	PutGroupKey(groupKey rpc.GroupKey, verificationShares rpc.VerificationShares) error
+
+	GetEpochMode() string
+	GetActiveEpoch() (uint, error)
+	GetGroupKey(epoch uint) (rpc.GroupKey, error)
+	GetVerificationShares(epoch uint) (rpc.VerificationShares, error)
+	GetPartiesOfEpoch(epoch uint) (rpc.Parties, error)
+	GetDKGComplaints(epoch uint) ([]rpc.DKGComplaint, error)
+	GetIdentityKeys(parties rpc.Parties) (rpc.IdentityKeys, error)
+	GetSigningKey(party string) (string, error)
+
+	PutNonceCommitments(epoch uint, party string, commitments []frost.NonceCommitment) error
+	AvailableNonceCommitments(epoch uint, party string) (int, error)
 }
 
-func NewEpochRunner(store Store, intialTick time.Duration, thresholdFactor float64, logger *logrus.Logger) Runner {
+// NewEpochRunner starts epochs in `epochMode` unless a mode was picked
+// through the store. New keys are generated for `ciphersuite`.
+func NewEpochRunner(store Store, intialTick time.Duration, thresholdFactor float64, noncePool NoncePool, ciphersuite frost.Ciphersuite, epochMode string, logger *logrus.Logger) Runner {
 	return &runner{
-		store:     store,
-		nextepoch: 1,
-		initTick:  intialTick,
-		logger:    logger,
+		store:    store,
+		initTick: intialTick,
+		logger:   logger,
 
 		thresholdFactor: thresholdFactor,
+		noncePool:       noncePool,
+		ciphersuite:     ciphersuite,
+		epochMode:       epochMode,
 	}
 }
 
+// dkgRun is the progress of the running dkg that is only held in memory. A
+// dkg cut short by a restart is given up, so it is never restored.
+type dkgRun struct {
+	packages     map[string]dkg.Round1Package
+	disqualified map[string]string
+}
+
+// Run steps through the phases of one epoch after the other, picking up
+// where the previous run stopped.
 func (r *runner) Run(epochDuration time.Duration) error {
+	state, err := r.recover()
+	if err != nil {
+		return err
+	}
+
 	// Initial Tick [wait for `initTick` for all nodes to be ready and send register message]
 	r.awaitInitialTick()
 
-	// in a for loop with a time out of `epoch timeout` start a new epoch
+	// lock system sigag ✅
+	// announce new epoch ✅
+	// ack from all parties and they lock their systems ✅
+	// store responded parties to the db for this epoch ✅
+
+	// DKG init
+	// choose n,k ✅
+	// Round 1 ✅
+	// send list of parties[] to all parties[] ✅
+	// parties do dkg among themselves
+	// - each party generates a polynomial using shamir secret sharing library ✅
+	// - compute POK of secret and commitments for polynomial generated ✅
+	// - each party broadcasts commitments and POC to all other parties O(n^2) network calls ✅
+	// - - if failed try again retry(with backoff) ✅
+	// - if everyone has acquired N commitments then we can start key gen else perform Round(1) of DKG again ✅
+	// SA receives Round 1 ACK ✅
+
+	// Round 2 ✅
+	// - each participant sends secret share for all N participants O(n^2) network calls and get verified accordingly ✅
+	// - participates calculate long lives secrets from ✅
+	// finally SA can calculate and publish Group Pubkey and Individual Party Pubkeys ✅
+
+	// preprocess ✅
+	// SA requests for nonces from all parties for next 10 txs ✅
+	// stores nonces in local db ✅
+	// tops up pools that fall below the low-water mark until the epoch ends ✅
+
+	// signing sessions run on demand through the signing coordinator
+	// chose a subset ✅
+	// send tx to choosen set ✅
+	// aggregate sigs ✅
+	run := &dkgRun{}
 	for {
-		// lock system sigag ✅
-		// announce new epoch ✅
-		// ack from all parties and they lock their systems ✅
-		// store responded parties to the db for this epoch ✅
-
-		// DKG init
-		// choose n,k ✅
-		// Round 1 ✅
-		// send list of parties[] to all parties[] ✅
-		// parties do dkg among themselves
-		// - each party generates a polynomial using shamir secret sharing library
-		// - compute POK of secret and commitments for polynomial generated
-		// - each party broadcasts commitments and POC to all other parties O(n^2) network calls
-		// - - if failed try again retry(with backoff)
-		// - if everyone has acquired N commitments then we can start key gen else perform Round(1) of DKG again
-		// SA receives Round 1 ACK
-
-		// Round 2
-		// - each participant sends secret share for all N participants O(n^2) network calls and get verified accordingly
-		// - participates calculate long lives secrets from
-		// finally SA can calculate and publish Group Pubkey and Individual Party Pubkeys
-
-		// preprocess
-		// SA requests for nonces from all parties for next 10 txs
-		// stores nonces in local db
-
-		// chose a subset
-		// send tx to choosen set
-		// aggregate sigs
-		r.store.Lock()
-		parties := r.store.GetPartyCLients()
-		partyMap, err := r.AnnounceNewEpoch(parties, r.nextepoch)
+		next, err := r.step(state, run, epochDuration)
 		if err != nil {
 			return err
 		}
 
-		if err := r.store.PutParties(partyMap); err != nil {
+		if err := r.store.PutEpochState(next); err != nil {
 			return err
 		}
 
-		TotalParties := len(partyMap)
-		Threshold := uint((float64(TotalParties) / r.thresholdFactor) + 1)
+		r.logger.Debugf("epoch %d moved from %s to %s", state.Epoch, state.Phase, next.Phase)
+		state = next
+	}
+}
 
-		if err := r.store.PutThreshold(Threshold, r.nextepoch); err != nil {
-			return err
+// recover restores the registered parties and the state the previous run
+// left behind. An epoch whose key was published carries on. A dkg that was
+// cut short is given up, as the packages and disqualifications collected so
+// far were lost, and the next epoch starts under a new number.
+func (r *runner) recover() (State, error) {
+	if err := r.store.RestoreParties(); err != nil {
+		return State{}, err
+	}
+
+	state, err := r.store.GetEpochState()
+	if err != nil {
+		return State{}, err
+	}
+
+	if state.Epoch == 0 {
+		state = State{Epoch: 1, Phase: PhaseRegistration}
+		return state, r.store.PutEpochState(state)
+	}
+
+	switch state.Phase {
+	case PhaseAnnounce, PhaseDKGRound1, PhaseDKGRound2:
+		state = r.fail(state, 0, fmt.Errorf("sigag restarted"))
+
+	case PhaseFinalize:
+		if active, err := r.store.GetActiveEpoch(); err == nil && active == state.Epoch {
+			r.logger.Infof("resuming epoch %d, its group key was published before the restart", state.Epoch)
+			state = State{Epoch: state.Epoch, Phase: PhasePreprocess, DKGInit: state.DKGInit}
+		} else {
+			state = r.fail(state, 0, fmt.Errorf("sigag restarted"))
 		}
 
-		if err := r.AnnounceDKGInit(r.store.GetPartyCLients(), partyMap, Threshold); err != nil {
-			r.logger.Errorf("failed to announce dkg init: %v", err)
-			continue
+	default:
+		r.logger.Infof("resuming epoch %d in phase %s", state.Epoch, state.Phase)
+		return state, nil
+	}
+
+	return state, r.store.PutEpochState(state)
+}
+
+// step does the work of the state's phase and returns the state that follows.
+func (r *runner) step(state State, run *dkgRun, epochDuration time.Duration) (State, error) {
+	switch state.Phase {
+	case PhaseRegistration:
+		return State{Epoch: state.Epoch, Phase: PhaseAnnounce}, nil
+
+	case PhaseAnnounce:
+		return r.announce(state, epochDuration)
+
+	case PhaseDKGRound1:
+		return r.dkgRound1(state, run, epochDuration), nil
+
+	case PhaseDKGRound2:
+		return r.dkgRound2(state, run, epochDuration), nil
+
+	case PhaseFinalize:
+		return r.finalize(state, run, epochDuration)
+
+	case PhasePreprocess:
+		return r.preprocess(state, epochDuration)
+
+	case PhaseActive, PhaseFailed:
+		// a failed epoch keeps signing with the active key
+		r.awaitActiveEpoch(state.Ends)
+		return State{Epoch: state.Epoch + 1, Phase: PhaseRegistration}, nil
+
+	default:
+		return State{}, fmt.Errorf("epoch %d is in unknown phase %s", state.Epoch, state.Phase)
+	}
+}
+
+// fail gives up the epoch, which waits out its duration before the next one
+// is tried.
+func (r *runner) fail(state State, epochDuration time.Duration, err error) State {
+	r.logger.Errorf("epoch %d failed in phase %s: %v", state.Epoch, state.Phase, err)
+	return State{
+		Epoch:   state.Epoch,
+		Phase:   PhaseFailed,
+		DKGInit: state.DKGInit,
+		Ends:    time.Now().Add(epochDuration),
+		Reason:  fmt.Sprintf("%s: %v", state.Phase, err),
+	}
+}
+
+// announce announces the epoch to the registered parties, records the ones
+// that answered and plans the epoch's dkg.
+func (r *runner) announce(state State, epochDuration time.Duration) (State, error) {
+	partyMap, err := r.AnnounceNewEpoch(r.store.GetPartyCLients(), state.Epoch)
+	if err != nil {
+		return State{}, err
+	}
+
+	if err := r.store.PutParties(partyMap, state.Epoch); err != nil {
+		return State{}, err
+	}
+
+	dkgInit, err := r.planDKG(partyMap, state.Epoch)
+	if err != nil {
+		return r.fail(state, epochDuration, fmt.Errorf("cannot plan the dkg: %w", err)), nil
+	}
+
+	if err := r.store.PutThreshold(dkgInit.Threshold, state.Epoch); err != nil {
+		return State{}, err
+	}
+
+	if err := r.store.PutCiphersuite(dkgInit.Ciphersuite, state.Epoch); err != nil {
+		return State{}, err
+	}
+
+	return State{Epoch: state.Epoch, Phase: PhaseDKGRound1, DKGInit: &dkgInit}, nil
+}
+
+// dkgRound1 hands every party the dkg parameters, has them broadcast their
+// round 1 packages and checks every party received the same package from
+// each dealer. A party that fails a step of the dkg or is found
+// dishonest when a complaint is judged is disqualified, and the remaining
+// parties carry on without it as long as they still meet the threshold.
+func (r *runner) dkgRound1(state State, run *dkgRun, epochDuration time.Duration) State {
+	parties := r.store.GetPartyCLients()
+	*run = dkgRun{disqualified: make(map[string]string)}
+
+	r.AnnounceDKGInit(parties, *state.DKGInit, run.disqualified)
+	if err := checkQualified(*state.DKGInit, run.disqualified); err != nil {
+		return r.fail(state, epochDuration, err)
+	}
+
+	run.packages = r.AnnounceDKGRound1(parties, state.Epoch, run.disqualified)
+	r.CheckRound1Broadcast(parties, *state.DKGInit, run.packages, run.disqualified)
+	if err := checkQualified(*state.DKGInit, run.disqualified); err != nil {
+		return r.fail(state, epochDuration, err)
+	}
+
+	return State{Epoch: state.Epoch, Phase: PhaseDKGRound2, DKGInit: state.DKGInit}
+}
+
+// dkgRound2 has the parties exchange their shares and judges the complaints
+// about them.
+func (r *runner) dkgRound2(state State, run *dkgRun, epochDuration time.Duration) State {
+	parties := r.store.GetPartyCLients()
+
+	r.AnnounceDKGRound2(parties, state.Epoch, run.disqualified)
+	r.AnnounceDKGDeliveries(parties, state.Epoch, run.disqualified)
+	if err := r.JudgeComplaints(parties, *state.DKGInit, run.packages, run.disqualified); err != nil {
+		return r.fail(state, epochDuration, err)
+	}
+	if err := checkQualified(*state.DKGInit, run.disqualified); err != nil {
+		return r.fail(state, epochDuration, err)
+	}
+
+	return State{Epoch: state.Epoch, Phase: PhaseFinalize, DKGInit: state.DKGInit}
+}
+
+// finalize collects the group key from the qualified parties and publishes
+// it, which makes the epoch the active one.
+func (r *runner) finalize(state State, run *dkgRun, epochDuration time.Duration) (State, error) {
+	dkgInit := *state.DKGInit
+
+	groupKey, verificationShares, err := r.AnnounceDKGFinalize(r.store.GetPartyCLients(), dkgInit, run.packages, run.disqualified)
+	if err != nil {
+		return r.fail(state, epochDuration, err), nil
+	}
+
+	if err := r.store.PutGroupKey(groupKey, verificationShares); err != nil {
+		return State{}, err
+	}
+
+	r.logger.Infof("epoch %d signs with group key %s (%s), %d parties disqualified", state.Epoch, groupKey.GroupPublicKey, dkgInit.Mode, len(groupKey.Disqualified))
+	return State{Epoch: state.Epoch, Phase: PhasePreprocess, DKGInit: state.DKGInit}, nil
+}
+
+// preprocess has the holders of a refreshed or re-shared key erase their old
+// shares and fills the nonce pools of the new key's holders.
+func (r *runner) preprocess(state State, epochDuration time.Duration) (State, error) {
+	if dkgInit := state.DKGInit; dkgInit != nil && (dkgInit.Mode == rpc.EpochModeRefresh || dkgInit.Mode == rpc.EpochModeReshare) {
+		r.retireEpoch(dkgInit.PreviousEpoch)
+	}
+
+	partyMap, err := r.store.GetPartiesOfEpoch(state.Epoch)
+	if err != nil {
+		return State{}, err
+	}
+
+	groupKey, err := r.store.GetGroupKey(state.Epoch)
+	if err != nil {
+		return State{}, err
+	}
+
+	r.Preprocess(r.store.GetPartyCLients(), shareHolders(partyMap, groupKey), state.Epoch)
+	return State{Epoch: state.Epoch, Phase: PhaseActive, DKGInit: state.DKGInit, Ends: time.Now().Add(epochDuration)}, nil
+}
+
+// planDKG decides how the epoch's key is generated and hands every party the
+// identifiers the dkg uses, so they can check they derive the same ones.
+func (r *runner) planDKG(partyMap rpc.Parties, epoch uint) (partyrpc.DKGInitRequest, error) {
+	dkgInit, err := r.planKey(partyMap, epoch)
+	if err != nil {
+		return partyrpc.DKGInitRequest{}, err
+	}
+
+	cs, err := frost.CiphersuiteByID(dkgInit.Ciphersuite)
+	if err != nil {
+		return partyrpc.DKGInitRequest{}, err
+	}
+
+	if dkgInit.Identifiers, err = dkg.Identifiers(cs.Group(), dkgInit.AllParties()); err != nil {
+		return partyrpc.DKGInitRequest{}, err
+	}
+
+	return dkgInit, nil
+}
+
+// planKey picks the mode of the epoch's dkg. While the parties of the active
+// epoch stay the same, a refresh keeps its ciphersuite, threshold and party
+// set, and the new key mode generates a new key. When the parties changed the
+// active key is re-shared to the new committee under a new threshold in
+// either mode, so a change of membership never moves the group key. Only when
+// too few holders of the active key are left to re-share it does the new key
+// mode start over with a new key. Without an active epoch a new key is
+// generated.
+func (r *runner) planKey(partyMap rpc.Parties, epoch uint) (partyrpc.DKGInitRequest, error) {
+	mode := r.store.GetEpochMode()
+	if mode == "" {
+		mode = r.epochMode
+	}
+
+	identityKeys, err := r.store.GetIdentityKeys(partyMap)
+	if err != nil {
+		return partyrpc.DKGInitRequest{}, err
+	}
+
+	signingKeys := make(map[string]string, len(partyMap))
+	for id := range partyMap {
+		if signingKeys[id], err = r.store.GetSigningKey(id); err != nil {
+			return partyrpc.DKGInitRequest{}, err
 		}
+	}
 
-		time.Sleep(epochDuration)
-		r.nextepoch++
+	dkgInit := partyrpc.DKGInitRequest{
+		Epoch:        epoch,
+		Parties:      partyMap,
+		IdentityKeys: identityKeys,
+		SigningKeys:  signingKeys,
+		Mode:         rpc.EpochModeNewKey,
+		Ciphersuite:  r.ciphersuite.ID(),
+		Threshold:    uint((float64(len(partyMap)) / r.thresholdFactor) + 1),
 	}
 
-	// unreachable
-	return nil
+	active, err := r.store.GetActiveEpoch()
+	if err != nil {
+		if mode == rpc.EpochModeRefresh {
+			r.logger.Infof("no active key to refresh in epoch %d, generating a new one", epoch)
+		}
+		return dkgInit, nil
+	}
+
+	groupKey, err := r.store.GetGroupKey(active)
+	if err != nil {
+		return partyrpc.DKGInitRequest{}, err
+	}
+
+	previousParties, err := r.store.GetPartiesOfEpoch(active)
+	if err != nil {
+		return partyrpc.DKGInitRequest{}, err
+	}
+
+	dealers := make([]string, 0, len(previousParties))
+	for id := range shareHolders(previousParties, groupKey) {
+		if _, ok := partyMap[id]; ok {
+			dealers = append(dealers, id)
+		}
+	}
+	sort.Strings(dealers)
+
+	if len(dealers) == len(previousParties) && len(partyMap) == len(previousParties) {
+		if mode != rpc.EpochModeRefresh {
+			return dkgInit, nil
+		}
+
+		dkgInit.Mode = rpc.EpochModeRefresh
+		dkgInit.PreviousEpoch = active
+		dkgInit.Ciphersuite = groupKey.Ciphersuite
+		dkgInit.Threshold = groupKey.Threshold
+		return dkgInit, nil
+	}
+
+	if uint(len(dealers)) < groupKey.Threshold {
+		if mode != rpc.EpochModeRefresh {
+			r.logger.Warnf("only %d parties of epoch %d are left to reshare its key with threshold %d, generating a new one", len(dealers), active, groupKey.Threshold)
+			return dkgInit, nil
+		}
+		return partyrpc.DKGInitRequest{}, fmt.Errorf("resharing needs %d parties of epoch %d, %d responded", groupKey.Threshold, active, len(dealers))
+	}
+
+	verificationShares, err := r.store.GetVerificationShares(active)
+	if err != nil {
+		return partyrpc.DKGInitRequest{}, err
+	}
+
+	dkgInit.Mode = rpc.EpochModeReshare
+	dkgInit.PreviousEpoch = active
+	dkgInit.Ciphersuite = groupKey.Ciphersuite
+	dkgInit.Resharing = &dkg.Resharing{
+		PreviousParties:    previousParties,
+		PreviousThreshold:  groupKey.Threshold,
+		Dealers:            dealers,
+		GroupPublicKey:     groupKey.GroupPublicKey,
+		VerificationShares: verificationShares,
+	}
+	r.logger.Infof("parties changed since epoch %d, resharing its key from %d of them to %d parties with threshold %d", active, len(dealers), len(partyMap), dkgInit.Threshold)
+	return dkgInit, nil
+}
+
+// awaitActiveEpoch keeps the active epoch's nonce pools topped up until
+// `ends`.
+func (r *runner) awaitActiveEpoch(ends time.Time) {
+	active, err := r.store.GetActiveEpoch()
+	if err != nil {
+		<-time.After(time.Until(ends))
+		return
+	}
+
+	partyMap, err := r.store.GetPartiesOfEpoch(active)
+	if err != nil {
+		<-time.After(time.Until(ends))
+		return
+	}
+
+	groupKey, err := r.store.GetGroupKey(active)
+	if err != nil {
+		<-time.After(time.Until(ends))
+		return
+	}
+
+	r.awaitEpochEnd(ends, shareHolders(partyMap, groupKey), active)
+}
+
+// shareHolders drops the parties disqualified from an epoch's DKG, which
+// hold no share of its key.
+func shareHolders(partyMap rpc.Parties, groupKey rpc.GroupKey) rpc.Parties {
+	holders := make(rpc.Parties, len(partyMap))
+	for id, url := range partyMap {
+		if _, ok := groupKey.Disqualified[id]; !ok {
+			holders[id] = url
+		}
+	}
+	return holders
 }
 
 func (r *runner) awaitInitialTick() {
@@ -115,6 +500,56 @@ func (r *runner) awaitInitialTick() {
 	<-time.After(r.initTick)
 }
 
+// awaitEpochEnd keeps nonce pools topped up until the epoch is over.
+func (r *runner) awaitEpochEnd(ends time.Time, partyMap rpc.Parties, epoch uint) {
+	ticker := time.NewTicker(replenishInterval)
+	defer ticker.Stop()
+
+	deadline := time.After(time.Until(ends))
+	for {
+		select {
+		case <-deadline:
+			return
+		case <-ticker.C:
+			r.Preprocess(r.store.GetPartyCLients(), partyMap, epoch)
+		}
+	}
+}
+
+// Preprocess tops up the nonce commitment pool of every epoch party whose
+// unused commitments fell below the low-water mark. A party that fails to
+// respond only loses its own pool top up.
+func (r *runner) Preprocess(parties *collections.OrderedList[partyclient.PartyClient], partyMap rpc.Parties, epoch uint) {
+	for _, v := range parties.Items {
+		if _, ok := partyMap[v.ID()]; !ok {
+			continue
+		}
+
+		available, err := r.store.AvailableNonceCommitments(epoch, v.ID())
+		if err != nil {
+			r.logger.Errorf("failed to read nonce pool of %s: %v", v.ID(), err)
+			continue
+		}
+
+		if uint(available) >= r.noncePool.LowWaterMark {
+			continue
+		}
+
+		commitments, err := v.Preprocess(epoch, r.noncePool.Size-uint(available))
+		if err != nil {
+			r.logger.Errorf("failed to preprocess nonces on %s: %v", v.ID(), err)
+			continue
+		}
+
+		if err := r.store.PutNonceCommitments(epoch, v.ID(), commitments); err != nil {
+			r.logger.Errorf("failed to store nonce commitments of %s: %v", v.ID(), err)
+			continue
+		}
+
+		r.logger.Infof("topped up nonce pool of %s with %d commitments for epoch %d", v.ID(), len(commitments), epoch)
+	}
+}
+
 func (r *runner) AnnounceNewEpoch(parties *collections.OrderedList[partyclient.PartyClient], epoch uint) (rpc.Parties, error) {
 	partyMap := make(rpc.Parties)
 	for _, v := range parties.Items {
@@ -132,12 +567,437 @@ func (r *runner) AnnounceNewEpoch(parties *collections.OrderedList[partyclient.P
 	return partyMap, nil
 }
 
-func (r *runner) AnnounceDKGInit(parties *collections.OrderedList[partyclient.PartyClient], partyMap rpc.Parties, threshold uint) error {
+// AnnounceDKGInit hands every party the parameters of the DKG. Parties that
+// refuse them are disqualified.
+func (r *runner) AnnounceDKGInit(parties *collections.OrderedList[partyclient.PartyClient], dkgInit partyrpc.DKGInitRequest, disqualified map[string]string) {
 	for _, v := range parties.Items {
-		if err := v.DKGInit(partyMap, threshold); err != nil {
-			r.logger.Errorf("failed to announce dkg init: %v", err)
+		if err := v.DKGInit(dkgInit); err != nil {
+			r.disqualify(disqualified, v.ID(), fmt.Sprintf("dkg init failed: %v", err))
+		}
+	}
+}
+
+// AnnounceDKGRound1 asks every qualified party to broadcast its round 1
+// package, once all of them have been initiated and can verify incoming
+// packages, and collects the packages to judge complaints against. Parties
+// that fail to broadcast are disqualified.
+func (r *runner) AnnounceDKGRound1(parties *collections.OrderedList[partyclient.PartyClient], epoch uint, disqualified map[string]string) map[string]dkg.Round1Package {
+	packages := make(map[string]dkg.Round1Package)
+	for _, v := range parties.Items {
+		if _, ok := disqualified[v.ID()]; ok {
+			continue
+		}
+
+		pkg, err := v.DKGRound1(epoch, sortedKeys(disqualified))
+		if err != nil {
+			r.disqualify(disqualified, v.ID(), fmt.Sprintf("dkg round 1 failed: %v", err))
+			continue
+		}
+
+		if pkg != nil {
+			packages[v.ID()] = *pkg
+		}
+	}
+	return packages
+}
+
+// CheckRound1Broadcast makes sure every qualified party received the round 1
+// package each dealer handed sigag, before any share is sent. A dealer that
+// handed sigag no package or one it did not sign is disqualified, packages of
+// parties that do not deal are dropped. Every party
+// reports the digests of the packages it received, and shows the signed
+// package behind a digest that differs from sigag's. That disqualifies the
+// dealer for signing two different packages, or the party for a report it
+// cannot back. A party that fails to report is disqualified.
+func (r *runner) CheckRound1Broadcast(parties *collections.OrderedList[partyclient.PartyClient], dkgInit partyrpc.DKGInitRequest, packages map[string]dkg.Round1Package, disqualified map[string]string) {
+	dealers := make(map[string]bool, len(dkgInit.Parties))
+	for id := range dkgInit.Parties {
+		dealers[id] = dkgInit.Resharing == nil
+	}
+	if dkgInit.Resharing != nil {
+		for _, id := range dkgInit.Resharing.Dealers {
+			dealers[id] = true
+		}
+	}
+
+	for id, deals := range dealers {
+		if _, ok := packages[id]; !ok && deals {
+			r.disqualify(disqualified, id, "handed sigag no round 1 package")
+		}
+	}
+
+	for dealer, pkg := range packages {
+		if !dealers[dealer] {
+			delete(packages, dealer)
+			continue
+		}
+
+		if pkg.Sender != dealer || pkg.Epoch != dkgInit.Epoch {
+			r.disqualify(disqualified, dealer, "handed sigag a round 1 package of another dkg")
+			continue
+		}
+
+		if err := identity.Verify(dkgInit.SigningKeys[dealer], pkg.SignedBytes(), pkg.Signature); err != nil {
+			r.disqualify(disqualified, dealer, fmt.Sprintf("handed sigag a round 1 package it did not sign: %v", err))
+		}
+	}
+
+	for _, v := range parties.Items {
+		if _, ok := disqualified[v.ID()]; ok {
+			continue
+		}
+
+		digests, err := v.DKGRound1Digests(dkgInit.Epoch)
+		if err != nil {
+			r.disqualify(disqualified, v.ID(), fmt.Sprintf("failed to report its round 1 packages: %v", err))
+			continue
+		}
+
+		for dealer, pkg := range packages {
+			if _, ok := disqualified[dealer]; ok || dealer == v.ID() {
+				continue
+			}
+
+			reported, ok := digests[dealer]
+			if !ok {
+				r.logger.Warnf("%s has not received the round 1 package of %s", v.ID(), dealer)
+				continue
+			}
+
+			if reported == pkg.Digest() {
+				continue
+			}
+
+			var shown *dkg.Round1Package
+			if received, err := v.DKGRound1Received(dkgInit.Epoch, dealer); err != nil {
+				r.logger.Errorf("%s did not show its round 1 package from %s: %v", v.ID(), dealer, err)
+			} else {
+				shown = &received
+			}
+
+			guilty, reason := dkg.JudgeRound1(dkgInit.SigningKeys[dealer], v.ID(), pkg, reported, shown)
+			r.disqualify(disqualified, guilty, reason)
+			if guilty == v.ID() {
+				break
+			}
+		}
+	}
+}
+
+// AnnounceDKGRound2 asks every qualified party to send its secret shares to
+// every other qualified party, once all round 1 packages have been exchanged.
+// Shares that do not arrive or do not verify are settled by complaints, so a
+// failed round 2 is not held against the sender here.
+func (r *runner) AnnounceDKGRound2(parties *collections.OrderedList[partyclient.PartyClient], epoch uint, disqualified map[string]string) {
+	for _, v := range parties.Items {
+		if _, ok := disqualified[v.ID()]; ok {
+			continue
+		}
+
+		if err := v.DKGRound2(epoch, sortedKeys(disqualified)); err != nil {
+			r.logger.Errorf("dkg round 2 on %s: %v", v.ID(), err)
+		}
+	}
+}
+
+// AnnounceDKGDeliveries has every qualified party handle the messages left
+// for it in its mailbox, so the round 2 shares that went through sigag are
+// checked and complained about before complaints are judged, and logs what
+// each party still waits for.
+func (r *runner) AnnounceDKGDeliveries(parties *collections.OrderedList[partyclient.PartyClient], epoch uint, disqualified map[string]string) {
+	for _, v := range parties.Items {
+		if _, ok := disqualified[v.ID()]; ok {
+			continue
+		}
+
+		deliveries, err := v.DKGDeliveries(epoch)
+		if err != nil {
+			r.logger.Errorf("failed to check the deliveries of %s: %v", v.ID(), err)
+			continue
+		}
+
+		if !deliveries.Done() {
+			r.logger.Warnf("%s still waits for round 1 packages from %v and round 2 shares from %v", v.ID(), deliveries.Round1, deliveries.Round2)
+		}
+	}
+}
+
+// JudgeComplaints asks every accused dealer to reveal the share it sent its
+// accuser and checks the share against the dealer's round 1 commitments. The
+// dealer is disqualified when the share does not match or it refuses to
+// reveal it, the accuser when it complained about a valid share.
+func (r *runner) JudgeComplaints(parties *collections.OrderedList[partyclient.PartyClient], dkgInit partyrpc.DKGInitRequest, packages map[string]dkg.Round1Package, disqualified map[string]string) error {
+	cs, err := frost.CiphersuiteByID(dkgInit.Ciphersuite)
+	if err != nil {
+		return err
+	}
+
+	complaints, err := r.store.GetDKGComplaints(dkgInit.Epoch)
+	if err != nil {
+		return err
+	}
+
+	clients := make(map[string]partyclient.PartyClient, len(parties.Items))
+	for _, v := range parties.Items {
+		clients[v.ID()] = v
+	}
+
+	for _, complaint := range complaints {
+		_, accuserOut := disqualified[complaint.Accuser]
+		_, accusedOut := disqualified[complaint.Accused]
+		if accuserOut || accusedOut {
+			continue
+		}
+
+		var revealed *dkg.Round2Share
+		if accused, ok := clients[complaint.Accused]; ok {
+			share, err := accused.DKGRevealShare(dkgInit.Epoch, complaint.Accuser)
+			if err != nil {
+				r.logger.Errorf("%s did not reveal its share for %s: %v", complaint.Accused, complaint.Accuser, err)
+			} else {
+				revealed = &share
+			}
+		}
+
+		guilty, reason := dkg.Judge(cs, dkgInit.Parties, complaint, packages[complaint.Accused], revealed)
+		r.disqualify(disqualified, guilty, reason)
+	}
+
+	return nil
+}
+
+// disqualify drops a party from the DKG, keeping the first reason it was
+// dropped for.
+func (r *runner) disqualify(disqualified map[string]string, party, reason string) {
+	if _, ok := disqualified[party]; ok {
+		return
+	}
+
+	r.logger.Errorf("disqualified %s from the dkg: %s", party, reason)
+	disqualified[party] = reason
+}
+
+// checkQualified makes sure the qualified parties still meet the threshold and
+// that a resharing kept all of its dealers.
+func checkQualified(dkgInit partyrpc.DKGInitRequest, disqualified map[string]string) error {
+	if dkgInit.Resharing != nil {
+		for _, dealer := range dkgInit.Resharing.Dealers {
+			if reason, ok := disqualified[dealer]; ok {
+				return fmt.Errorf("resharing lost dealer %s: %s", dealer, reason)
+			}
+		}
+	}
+
+	qualified := uint(len(dkgInit.Parties) - len(disqualified))
+	if qualified < dkgInit.Threshold {
+		return fmt.Errorf("only %d qualified parties for threshold %d, disqualified %v", qualified, dkgInit.Threshold, disqualified)
+	}
+
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// AnnounceDKGFinalize asks every qualified party to derive its long-lived key
+// material and collects the group key and verification shares they report.
+// Sigag derives both from the round 1 packages of the qualified dealers, which
+// every party was checked to hold. Parties that fail to finalize or report
+// anything else are disqualified and get no verification share. A refresh or
+// resharing must keep the previous key.
+func (r *runner) AnnounceDKGFinalize(parties *collections.OrderedList[partyclient.PartyClient], dkgInit partyrpc.DKGInitRequest, packages map[string]dkg.Round1Package, disqualified map[string]string) (rpc.GroupKey, rpc.VerificationShares, error) {
+	cs, err := frost.CiphersuiteByID(dkgInit.Ciphersuite)
+	if err != nil {
+		return rpc.GroupKey{}, nil, err
+	}
+
+	partyMap, threshold, epoch := dkgInit.Parties, dkgInit.Threshold, dkgInit.Epoch
+
+	identifiers, err := dkg.Identifiers(cs.Group(), partyMap)
+	if err != nil {
+		return rpc.GroupKey{}, nil, err
+	}
+
+	qualified := make(map[string]dkg.Round1Package, len(packages))
+	for id, pkg := range packages {
+		if _, ok := disqualified[id]; !ok {
+			qualified[id] = pkg
+		}
+	}
+
+	var (
+		previousKey    *group.Element
+		previousShares rpc.VerificationShares
+	)
+	if dkgInit.Mode == rpc.EpochModeRefresh {
+		previous, err := r.store.GetGroupKey(dkgInit.PreviousEpoch)
+		if err != nil {
+			return rpc.GroupKey{}, nil, err
+		}
+
+		if previousShares, err = r.store.GetVerificationShares(dkgInit.PreviousEpoch); err != nil {
+			return rpc.GroupKey{}, nil, err
+		}
+		previousKey = previous.GroupPublicKey
+	}
+
+	groupPublicKey, expected := dkg.PublicKeys(cs.Group(), identifiers, qualified, previousKey, previousShares)
+	verificationShares := make(rpc.VerificationShares, len(partyMap))
+
+	excluded := sortedKeys(disqualified)
+	for _, v := range parties.Items {
+		if _, ok := disqualified[v.ID()]; ok {
+			continue
+		}
+
+		response, err := v.DKGFinalize(epoch, excluded)
+		if err != nil {
+			r.disqualify(disqualified, v.ID(), fmt.Sprintf("dkg finalize failed: %v", err))
+			continue
+		}
+
+		if response.GroupPublicKey == nil || response.VerificationShare == nil {
+			r.disqualify(disqualified, v.ID(), "reported an incomplete key")
+			continue
+		}
+
+		if response.GroupPublicKey.Group() != cs.Group() || response.VerificationShare.Group() != cs.Group() {
+			r.disqualify(disqualified, v.ID(), fmt.Sprintf("reported a key outside %s", cs.Group().Name()))
+			continue
+		}
+
+		if !response.GroupPublicKey.Equal(groupPublicKey) {
+			r.disqualify(disqualified, v.ID(), fmt.Sprintf("reported group key %s, the round 1 packages give %s", response.GroupPublicKey, groupPublicKey))
+			continue
+		}
+
+		if !response.VerificationShare.Equal(expected[v.ID()]) {
+			r.disqualify(disqualified, v.ID(), "reported a verification share the round 1 packages do not give")
+			continue
+		}
+
+		verificationShares[v.ID()] = response.VerificationShare
+	}
+
+	if err := checkVerificationShares(groupPublicKey, verificationShares, identifiers, threshold); err != nil {
+		return rpc.GroupKey{}, nil, err
+	}
+
+	groupKey := rpc.GroupKey{
+		Epoch:          epoch,
+		Ciphersuite:    cs.ID(),
+		Threshold:      threshold,
+		GroupPublicKey: groupPublicKey,
+	}
+	if len(disqualified) > 0 {
+		groupKey.Disqualified = disqualified
+	}
+
+	if dkgInit.Mode == rpc.EpochModeRefresh || dkgInit.Mode == rpc.EpochModeReshare {
+		previous, err := r.store.GetGroupKey(dkgInit.PreviousEpoch)
+		if err != nil {
+			return rpc.GroupKey{}, nil, err
+		}
+
+		if !previous.GroupPublicKey.Equal(groupPublicKey) {
+			return rpc.GroupKey{}, nil, fmt.Errorf("%s moved the group key of epoch %d to %s", dkgInit.Mode, dkgInit.PreviousEpoch, groupPublicKey)
+		}
+
+		if dkgInit.Mode == rpc.EpochModeRefresh {
+			groupKey.RefreshedFrom = dkgInit.PreviousEpoch
+		} else {
+			groupKey.ResharedFrom = dkgInit.PreviousEpoch
+		}
+	}
+	if groupPublicKey.Group() == group.Secp256k1 {
+		groupKey.XOnlyPublicKey = hex.EncodeToString(frost.XOnly(groupPublicKey))
+	}
+
+	return groupKey, verificationShares, nil
+}
+
+// retireEpoch has the holders of a refreshed or re-shared key that are still
+// around erase their old shares.
+func (r *runner) retireEpoch(epoch uint) {
+	previousParties, err := r.store.GetPartiesOfEpoch(epoch)
+	if err != nil {
+		r.logger.Errorf("failed to read parties of epoch %d: %v", epoch, err)
+		return
+	}
+
+	r.AnnounceRetireEpoch(r.store.GetPartyCLients(), previousParties, epoch)
+}
+
+// AnnounceRetireEpoch asks the parties to erase their shares of an epoch that
+// was refreshed or re-shared. A party that misses the request keeps a share that is useless
+// without the old shares of threshold-1 other parties.
+func (r *runner) AnnounceRetireEpoch(parties *collections.OrderedList[partyclient.PartyClient], partyMap rpc.Parties, epoch uint) {
+	for _, v := range parties.Items {
+		if _, ok := partyMap[v.ID()]; !ok {
+			continue
+		}
+
+		if err := v.RetireEpoch(epoch); err != nil {
+			r.logger.Errorf("failed to retire epoch %d on %s: %v", epoch, v.ID(), err)
+		}
+	}
+}
+
+// checkVerificationShares makes sure the reported verification shares lie on
+// a single degree threshold-1 polynomial whose constant term is the group key,
+// i.e. that every threshold-sized subset of parties signs for the same key.
+func checkVerificationShares(groupPublicKey *group.Element, verificationShares rpc.VerificationShares, identifiers map[string]*group.Scalar, threshold uint) error {
+	ids := make([]string, 0, len(verificationShares))
+	for id := range verificationShares {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	if uint(len(ids)) < threshold {
+		return fmt.Errorf("only %d verification shares for threshold %d", len(ids), threshold)
+	}
+
+	base := make([]*group.Scalar, threshold)
+	for i, id := range ids[:threshold] {
+		base[i] = identifiers[id]
+	}
+
+	g := groupPublicKey.Group()
+	interpolate := func(x *group.Scalar) (*group.Element, error) {
+		result := g.Identity()
+		for i, id := range ids[:threshold] {
+			lambda, err := sss.LagrangeCoefficientAt(base[i], base, x)
+			if err != nil {
+				return nil, err
+			}
+			result = result.Add(verificationShares[id].ScalarMult(lambda))
+		}
+		return result, nil
+	}
+
+	key, err := interpolate(group.ScalarFromInt(g, 0))
+	if err != nil {
+		return err
+	}
+	if !key.Equal(groupPublicKey) {
+		return fmt.Errorf("verification shares do not interpolate to the group key")
+	}
+
+	for _, id := range ids[threshold:] {
+		expected, err := interpolate(identifiers[id])
+		if err != nil {
 			return err
 		}
+		if !expected.Equal(verificationShares[id]) {
+			return fmt.Errorf("verification share of %s is inconsistent with the group key", id)
+		}
 	}
+
 	return nil
 }
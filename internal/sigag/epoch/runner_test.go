@@ -0,0 +1,424 @@
+package epoch_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"frost/internal/party/dkg"
+	"frost/internal/party/identity"
+	"frost/internal/party/partyclient"
+	partyrpc "frost/internal/party/rpc"
+	"frost/internal/sigag/epoch"
+	"frost/internal/sigag/rpc"
+	"frost/internal/sigag/store"
+	"frost/pkg/collections"
+	"frost/pkg/frost"
+	"frost/pkg/group"
+	"frost/pkg/types"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rosedblabs/rosedb/v2"
+	"github.com/sirupsen/logrus"
+)
+
+var _ = Describe("Runner", func() {
+	var (
+		dir    string
+		db     *rosedb.DB
+		s      store.Store
+		logger *logrus.Logger
+	)
+
+	// restart opens the database again, as a restarted sigag would.
+	restart := func() {
+		if db != nil {
+			Expect(db.Close()).To(Succeed())
+		}
+
+		options := rosedb.DefaultOptions
+		options.DirPath = dir
+		var err error
+		db, err = rosedb.Open(options)
+		Expect(err).To(BeNil())
+
+		s = store.New(collections.NewOrderedList[partyclient.PartyClient](), db)
+	}
+
+	newRunner := func() epoch.Runner {
+		return epoch.NewEpochRunner(s, 0, 2, epoch.NoncePool{Size: 10, LowWaterMark: 3}, frost.Secp256k1SHA256, rpc.EpochModeNewKey, logger)
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "sigag_epoch")
+		Expect(err).To(BeNil())
+
+		logger = logrus.New()
+		logger.SetOutput(io.Discard)
+
+		db = nil
+		restart()
+	})
+
+	AfterEach(func() {
+		_ = db.Close()
+		_ = os.RemoveAll(dir)
+	})
+
+	It("should start at the registration of epoch 1", func() {
+		state, err := epoch.Recover(newRunner())
+		Expect(err).To(BeNil())
+		Expect(state).To(Equal(epoch.State{Epoch: 1, Phase: epoch.PhaseRegistration}))
+
+		persisted, err := s.GetEpochState()
+		Expect(err).To(BeNil())
+		Expect(persisted).To(Equal(state))
+	})
+
+	for _, phase := range []epoch.Phase{epoch.PhaseAnnounce, epoch.PhaseDKGRound1, epoch.PhaseDKGRound2} {
+		phase := phase
+
+		It("should give up a dkg cut short in "+string(phase), func() {
+			dkgInit := &partyrpc.DKGInitRequest{Epoch: 3, Threshold: 2}
+			Expect(s.PutEpochState(epoch.State{Epoch: 3, Phase: phase, DKGInit: dkgInit})).To(Succeed())
+			Expect(s.IsLocked()).To(BeTrue())
+
+			restart()
+			state, err := epoch.Recover(newRunner())
+			Expect(err).To(BeNil())
+			Expect(state.Epoch).To(Equal(uint(3)))
+			Expect(state.Phase).To(Equal(epoch.PhaseFailed))
+			Expect(state.Reason).To(ContainSubstring("sigag restarted"))
+			Expect(s.IsLocked()).To(BeFalse())
+
+			persisted, err := s.GetEpochState()
+			Expect(err).To(BeNil())
+			Expect(persisted.Phase).To(Equal(epoch.PhaseFailed))
+		})
+	}
+
+	It("should resume an epoch whose group key was published before the restart", func() {
+		dkgInit := &partyrpc.DKGInitRequest{Epoch: 2, Threshold: 2}
+		Expect(s.PutEpochState(epoch.State{Epoch: 2, Phase: epoch.PhaseFinalize, DKGInit: dkgInit})).To(Succeed())
+
+		g := frost.Secp256k1SHA256.Group()
+		groupKey := rpc.GroupKey{Epoch: 2, Threshold: 2, GroupPublicKey: group.ScalarBaseMult(group.ScalarFromInt(g, 7))}
+		Expect(s.PutGroupKey(groupKey, rpc.VerificationShares{})).To(Succeed())
+
+		restart()
+		state, err := epoch.Recover(newRunner())
+		Expect(err).To(BeNil())
+		Expect(state.Epoch).To(Equal(uint(2)))
+		Expect(state.Phase).To(Equal(epoch.PhasePreprocess))
+		Expect(state.DKGInit.Threshold).To(Equal(uint(2)))
+	})
+
+	It("should give up a finalize whose group key was not published", func() {
+		Expect(s.PutEpochState(epoch.State{Epoch: 2, Phase: epoch.PhaseFinalize})).To(Succeed())
+
+		restart()
+		state, err := epoch.Recover(newRunner())
+		Expect(err).To(BeNil())
+		Expect(state.Phase).To(Equal(epoch.PhaseFailed))
+	})
+
+	It("should resume an active epoch until it ends", func() {
+		ends := time.Now().Add(time.Hour).Round(0)
+		Expect(s.PutEpochState(epoch.State{Epoch: 4, Phase: epoch.PhaseActive, Ends: ends})).To(Succeed())
+
+		restart()
+		state, err := epoch.Recover(newRunner())
+		Expect(err).To(BeNil())
+		Expect(state.Epoch).To(Equal(uint(4)))
+		Expect(state.Phase).To(Equal(epoch.PhaseActive))
+		Expect(state.Ends.Equal(ends)).To(BeTrue())
+	})
+
+	It("should never hand out an epoch number twice", func() {
+		Expect(s.PutEpochState(epoch.State{Epoch: 5, Phase: epoch.PhaseDKGRound2})).To(Succeed())
+
+		restart()
+		r := newRunner()
+		state, err := epoch.Recover(r)
+		Expect(err).To(BeNil())
+		Expect(state.Phase).To(Equal(epoch.PhaseFailed))
+
+		next, err := epoch.Step(r, state, 0)
+		Expect(err).To(BeNil())
+		Expect(next).To(Equal(epoch.State{Epoch: 6, Phase: epoch.PhaseRegistration}))
+		Expect(s.PutEpochState(next)).To(Succeed())
+
+		restart()
+		Expect(s.PutEpochState(epoch.State{Epoch: 5, Phase: epoch.PhaseRegistration})).ToNot(Succeed())
+
+		state, err = epoch.Recover(newRunner())
+		Expect(err).To(BeNil())
+		Expect(state).To(Equal(epoch.State{Epoch: 6, Phase: epoch.PhaseRegistration}))
+	})
+
+	Context("When planning the dkg of an epoch", func() {
+		var (
+			party    *httptest.Server
+			groupKey rpc.GroupKey
+		)
+
+		// register has a party that answers pings register with fresh
+		// identity keys.
+		register := func(address string) {
+			key, err := identity.Generate()
+			Expect(err).To(BeNil())
+
+			Expect(s.AddParticipant(rpc.RegisterParty{
+				Address:     address,
+				Url:         strings.TrimPrefix(party.URL, "http://"),
+				IdentityKey: key.PublicKey(),
+				SigningKey:  key.SigningKey(),
+				NoTLS:       true,
+			})).To(Succeed())
+		}
+
+		// without drops a party from a party map.
+		without := func(partyMap rpc.Parties, id string) rpc.Parties {
+			kept := make(rpc.Parties, len(partyMap))
+			for k, v := range partyMap {
+				if k != id {
+					kept[k] = v
+				}
+			}
+			return kept
+		}
+
+		BeforeEach(func() {
+			party = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_ = json.NewEncoder(w).Encode(types.JSONResponse{JSONRPC: types.Version, Result: json.RawMessage(`{"message":"pong"}`), ID: 1})
+			}))
+			DeferCleanup(party.Close)
+
+			for _, address := range []string{"8801", "8802", "8803", "8804"} {
+				register(address)
+			}
+
+			// epoch 1 was run by the first three parties
+			Expect(s.PutParties(without(s.GetParties(), "8804"), 1)).To(Succeed())
+
+			g := frost.Secp256k1SHA256.Group()
+			groupKey = rpc.GroupKey{
+				Epoch:          1,
+				Ciphersuite:    frost.Secp256k1SHA256.ID(),
+				Threshold:      2,
+				GroupPublicKey: group.ScalarBaseMult(group.ScalarFromInt(g, 7)),
+			}
+			Expect(s.PutGroupKey(groupKey, rpc.VerificationShares{})).To(Succeed())
+		})
+
+		It("should generate a new key in new_key mode while the parties stay the same", func() {
+			dkgInit, err := epoch.PlanDKG(newRunner(), without(s.GetParties(), "8804"), 2)
+			Expect(err).To(BeNil())
+			Expect(dkgInit.Mode).To(Equal(rpc.EpochModeNewKey))
+			Expect(dkgInit.Resharing).To(BeNil())
+		})
+
+		It("should reshare the active key in new_key mode when a party dropped out", func() {
+			partyMap := without(without(s.GetParties(), "8804"), "8803")
+
+			dkgInit, err := epoch.PlanDKG(newRunner(), partyMap, 2)
+			Expect(err).To(BeNil())
+			Expect(dkgInit.Mode).To(Equal(rpc.EpochModeReshare))
+			Expect(dkgInit.PreviousEpoch).To(Equal(uint(1)))
+			Expect(dkgInit.Resharing.Dealers).To(Equal([]string{"8801", "8802"}))
+			Expect(dkgInit.Resharing.GroupPublicKey.Equal(groupKey.GroupPublicKey)).To(BeTrue())
+		})
+
+		It("should reshare the active key in new_key mode when a party registered", func() {
+			dkgInit, err := epoch.PlanDKG(newRunner(), s.GetParties(), 2)
+			Expect(err).To(BeNil())
+			Expect(dkgInit.Mode).To(Equal(rpc.EpochModeReshare))
+			Expect(dkgInit.Parties).To(HaveKey("8804"))
+			Expect(dkgInit.Resharing.Dealers).To(Equal([]string{"8801", "8802", "8803"}))
+			Expect(dkgInit.Resharing.GroupPublicKey.Equal(groupKey.GroupPublicKey)).To(BeTrue())
+		})
+
+		It("should only generate a new key when too few holders of the active key are left", func() {
+			dkgInit, err := epoch.PlanDKG(newRunner(), rpc.Parties{"8801": s.GetParties()["8801"], "8804": s.GetParties()["8804"]}, 2)
+			Expect(err).To(BeNil())
+			Expect(dkgInit.Mode).To(Equal(rpc.EpochModeNewKey))
+
+			Expect(s.PutEpochMode(rpc.EpochModeRefresh)).To(Succeed())
+			_, err = epoch.PlanDKG(newRunner(), rpc.Parties{"8801": s.GetParties()["8801"], "8804": s.GetParties()["8804"]}, 2)
+			Expect(err).ToNot(BeNil())
+		})
+	})
+})
+
+// dealer is a stub party of a dkg that reports the round 1 packages it
+// received, or misreports them.
+type dealer struct {
+	partyclient.PartyClient
+
+	id       string
+	received map[string]dkg.Round1Package
+	// reported replaces the digests of the packages it received.
+	reported map[string]string
+	finalize partyrpc.DKGFinalizeResponse
+}
+
+func (d *dealer) ID() string {
+	return d.id
+}
+
+func (d *dealer) DKGRound1Digests(uint) (map[string]string, error) {
+	digests := make(map[string]string, len(d.received))
+	for id, pkg := range d.received {
+		digests[id] = pkg.Digest()
+	}
+	for id, digest := range d.reported {
+		digests[id] = digest
+	}
+	return digests, nil
+}
+
+func (d *dealer) DKGRound1Received(_ uint, from string) (dkg.Round1Package, error) {
+	pkg, ok := d.received[from]
+	if !ok {
+		return dkg.Round1Package{}, fmt.Errorf("no round 1 package from %s", from)
+	}
+	return pkg, nil
+}
+
+func (d *dealer) DKGFinalize(uint, []string) (partyrpc.DKGFinalizeResponse, error) {
+	return d.finalize, nil
+}
+
+var _ = Describe("Runner DKG", func() {
+	var (
+		cs       = frost.Secp256k1SHA256
+		ids      = []string{"8801", "8802", "8803"}
+		keys     map[string]*identity.Key
+		dkgInit  partyrpc.DKGInitRequest
+		packages map[string]dkg.Round1Package
+		dealers  map[string]*dealer
+		parties  *collections.OrderedList[partyclient.PartyClient]
+		r        epoch.Runner
+	)
+
+	// round1 is a new round 1 package of `id` signed with `signer`'s key.
+	round1 := func(id, signer string) dkg.Round1Package {
+		participant, err := dkg.NewParticipant(1, cs, id, dkgInit.Parties, dkgInit.Threshold)
+		Expect(err).To(BeNil())
+
+		pkg, err := participant.DkGRound1()
+		Expect(err).To(BeNil())
+
+		pkg.Signature = keys[signer].Sign(pkg.SignedBytes())
+		return pkg
+	}
+
+	BeforeEach(func() {
+		keys = make(map[string]*identity.Key)
+		dkgInit = partyrpc.DKGInitRequest{Epoch: 1, Ciphersuite: cs.ID(), Parties: rpc.Parties{}, Threshold: 2, SigningKeys: map[string]string{}}
+		for _, id := range ids {
+			key, err := identity.Generate()
+			Expect(err).To(BeNil())
+
+			keys[id] = key
+			dkgInit.Parties[id] = "http://127.0.0.1:" + id
+			dkgInit.SigningKeys[id] = key.SigningKey()
+		}
+
+		packages = make(map[string]dkg.Round1Package)
+		for _, id := range ids {
+			packages[id] = round1(id, id)
+		}
+
+		dealers = make(map[string]*dealer)
+		parties = collections.NewOrderedList[partyclient.PartyClient]()
+		for _, id := range ids {
+			received := make(map[string]dkg.Round1Package)
+			for from, pkg := range packages {
+				received[from] = pkg
+			}
+
+			dealers[id] = &dealer{id: id, received: received, reported: map[string]string{}}
+			parties.Add(dealers[id])
+		}
+
+		logger := logrus.New()
+		logger.SetOutput(io.Discard)
+		r = epoch.NewEpochRunner(nil, 0, 2, epoch.NoncePool{}, cs, rpc.EpochModeNewKey, logger)
+	})
+
+	Context("When checking the round 1 broadcast", func() {
+		It("should keep dealers that sent everyone the package they handed sigag", func() {
+			disqualified := map[string]string{}
+			epoch.CheckRound1Broadcast(r, parties, dkgInit, packages, disqualified)
+			Expect(disqualified).To(BeEmpty())
+		})
+
+		It("should disqualify a dealer that signed another package for one party", func() {
+			dealers["8803"].received["8801"] = round1("8801", "8801")
+
+			disqualified := map[string]string{}
+			epoch.CheckRound1Broadcast(r, parties, dkgInit, packages, disqualified)
+			Expect(disqualified).To(HaveLen(1))
+			Expect(disqualified["8801"]).To(ContainSubstring("another round 1 package"))
+		})
+
+		It("should disqualify a party that reports a package it did not receive", func() {
+			dealers["8803"].reported["8801"] = "00"
+
+			disqualified := map[string]string{}
+			epoch.CheckRound1Broadcast(r, parties, dkgInit, packages, disqualified)
+			Expect(disqualified).To(HaveLen(1))
+			Expect(disqualified).To(HaveKey("8803"))
+		})
+
+		It("should disqualify a party that shows a package the dealer did not sign", func() {
+			dealers["8803"].received["8801"] = round1("8801", "8803")
+
+			disqualified := map[string]string{}
+			epoch.CheckRound1Broadcast(r, parties, dkgInit, packages, disqualified)
+			Expect(disqualified).To(HaveLen(1))
+			Expect(disqualified["8803"]).To(ContainSubstring("did not sign"))
+		})
+
+		It("should disqualify a dealer that handed sigag no signed package", func() {
+			unsigned := packages["8802"]
+			unsigned.Signature = nil
+			packages["8802"] = unsigned
+			delete(packages, "8803")
+
+			disqualified := map[string]string{}
+			epoch.CheckRound1Broadcast(r, parties, dkgInit, packages, disqualified)
+			Expect(disqualified).To(HaveLen(2))
+			Expect(disqualified["8802"]).To(ContainSubstring("did not sign"))
+			Expect(disqualified["8803"]).To(ContainSubstring("no round 1 package"))
+		})
+	})
+
+	Context("When finalizing", func() {
+		It("should disqualify a party that reports another group key rather than give up", func() {
+			identifiers, err := dkg.Identifiers(cs.Group(), dkgInit.Parties)
+			Expect(err).To(BeNil())
+
+			groupPublicKey, verificationShares := dkg.PublicKeys(cs.Group(), identifiers, packages, nil, nil)
+			for _, id := range ids {
+				dealers[id].finalize = partyrpc.DKGFinalizeResponse{GroupPublicKey: groupPublicKey, VerificationShare: verificationShares[id]}
+			}
+			dealers["8803"].finalize.GroupPublicKey = groupPublicKey.Add(group.ScalarBaseMult(group.ScalarFromInt(cs.Group(), 1)))
+
+			disqualified := map[string]string{}
+			groupKey, reported, err := epoch.AnnounceDKGFinalize(r, parties, dkgInit, packages, disqualified)
+			Expect(err).To(BeNil())
+			Expect(groupKey.GroupPublicKey.Equal(groupPublicKey)).To(BeTrue())
+			Expect(disqualified).To(HaveKey("8803"))
+			Expect(reported).To(HaveLen(2))
+			Expect(reported).ToNot(HaveKey("8803"))
+		})
+	})
+})
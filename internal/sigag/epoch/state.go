@@ -0,0 +1,57 @@
+package epoch
+
+import (
+	partyrpc "frost/internal/party/rpc"
+	"time"
+)
+
+// Phase is the step an epoch is in. The runner persists every change of
+// phase before it acts on it, so a restarted sigag knows where it stopped.
+type Phase string
+
+const (
+	// PhaseRegistration waits for parties to register before the epoch is
+	// announced. No party has heard of the epoch yet.
+	PhaseRegistration Phase = "registration"
+	// PhaseAnnounce announces the epoch and plans its dkg.
+	PhaseAnnounce Phase = "announce"
+	// PhaseDKGRound1 hands out the dkg parameters and has the parties
+	// broadcast their round 1 packages.
+	PhaseDKGRound1 Phase = "dkg_round1"
+	// PhaseDKGRound2 has the parties exchange their shares and judges the
+	// complaints about them.
+	PhaseDKGRound2 Phase = "dkg_round2"
+	// PhaseFinalize collects and publishes the group key.
+	PhaseFinalize Phase = "finalize"
+	// PhasePreprocess retires the previous key and fills the nonce pools.
+	PhasePreprocess Phase = "preprocess"
+	// PhaseActive signs with the epoch's key until the epoch ends.
+	PhaseActive Phase = "active"
+	// PhaseFailed keeps signing with the previous key until the epoch ends.
+	PhaseFailed Phase = "failed"
+)
+
+// Locked reports whether the epoch's dkg is running, during which parties
+// can neither register nor repair their shares.
+func (p Phase) Locked() bool {
+	switch p {
+	case PhaseAnnounce, PhaseDKGRound1, PhaseDKGRound2, PhaseFinalize:
+		return true
+	default:
+		return false
+	}
+}
+
+// State is the persisted progress of the latest epoch. Epoch numbers only
+// grow, an epoch that was announced is never started again.
+type State struct {
+	Epoch uint  `json:"epoch"`
+	Phase Phase `json:"phase"`
+
+	// DKGInit is the plan of the epoch's dkg, known from PhaseDKGRound1 on.
+	DKGInit *partyrpc.DKGInitRequest `json:"dkg_init,omitempty"`
+	// Ends is when an active or failed epoch makes way for the next one.
+	Ends time.Time `json:"ends"`
+	// Reason is why a failed epoch was given up.
+	Reason string `json:"reason,omitempty"`
+}
@@ -1,8 +1,33 @@
 package sigag
 
-import "github.com/sirupsen/logrus"
+import (
+	"frost/pkg/frost"
+
+	"github.com/sirupsen/logrus"
+)
 
 type Options struct {
 	Logger *logrus.Logger
 	Port   string
+
+	// NoncePoolSize is the number of nonce commitments sigag keeps per party.
+	NoncePoolSize uint
+	// NonceLowWaterMark triggers a top up once a party has fewer unused
+	// commitments left.
+	NonceLowWaterMark uint
+
+	// Ciphersuite is the FROST ciphersuite new epochs generate keys for.
+	// Defaults to FROST(secp256k1, SHA-256).
+	Ciphersuite frost.Ciphersuite
+
+	// EpochMode is rpc.EpochModeNewKey or rpc.EpochModeRefresh and applies
+	// until another mode is picked through the set_epoch_mode rpc. Defaults
+	// to a new key per epoch. In either mode a change of the parties
+	// re-shares the active key rather than replacing it.
+	EpochMode string
 }
+
+const (
+	DefaultNoncePoolSize     = 10
+	DefaultNonceLowWaterMark = 3
+)
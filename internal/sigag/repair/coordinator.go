@@ -0,0 +1,157 @@
+// share repairs driven by the signature aggregator
+package repair
+
+import (
+	"fmt"
+	"frost/internal/party/dkg"
+	"frost/internal/party/partyclient"
+	"frost/internal/sigag/rpc"
+	"frost/pkg/collections"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Store interface {
+	GetPartyCLients() *collections.OrderedList[partyclient.PartyClient]
+
+	GetActiveEpoch() (uint, error)
+	GetPartiesOfEpoch(epoch uint) (rpc.Parties, error)
+	GetGroupKey(epoch uint) (rpc.GroupKey, error)
+	GetVerificationShares(epoch uint) (rpc.VerificationShares, error)
+
+	RetireNonceCommitments(epoch uint, party string) (uint, error)
+	RenewIdentityKey(party string) (bool, error)
+}
+
+type coordinator struct {
+	store  Store
+	logger *logrus.Logger
+}
+
+func NewCoordinator(store Store, logger *logrus.Logger) rpc.Repairer {
+	return &coordinator{store: store, logger: logger}
+}
+
+// Repair rebuilds the share `party` holds in the active epoch with the help
+// of `threshold` other parties of the epoch. The nonce commitments sigag
+// still holds for the party are retired, their secret halves are gone. A
+// party that lost its disk registered new identity keys, which the repair
+// adopts so that the helpers seal their σ to them.
+func (c *coordinator) Repair(party string) (rpc.ShareRepair, error) {
+	epoch, err := c.store.GetActiveEpoch()
+	if err != nil {
+		return rpc.ShareRepair{}, err
+	}
+
+	groupKey, err := c.store.GetGroupKey(epoch)
+	if err != nil {
+		return rpc.ShareRepair{}, err
+	}
+
+	verificationShares, err := c.store.GetVerificationShares(epoch)
+	if err != nil {
+		return rpc.ShareRepair{}, err
+	}
+
+	partyMap, err := c.store.GetPartiesOfEpoch(epoch)
+	if err != nil {
+		return rpc.ShareRepair{}, err
+	}
+
+	if _, ok := verificationShares[party]; !ok {
+		return rpc.ShareRepair{}, fmt.Errorf("party %s holds no share of epoch %d", party, epoch)
+	}
+
+	lost, helpers, err := c.chooseHelpers(party, verificationShares, groupKey.Threshold)
+	if err != nil {
+		return rpc.ShareRepair{}, err
+	}
+
+	repair := dkg.Repair{Epoch: epoch, Lost: party, Parties: partyMap}
+	for _, helper := range helpers {
+		repair.Helpers = append(repair.Helpers, helper.ID())
+	}
+
+	nonceIndex, err := c.store.RetireNonceCommitments(epoch, party)
+	if err != nil {
+		return rpc.ShareRepair{}, err
+	}
+
+	renewed, err := c.store.RenewIdentityKey(party)
+	if err != nil {
+		return rpc.ShareRepair{}, err
+	}
+	if renewed {
+		c.logger.Infof("party %s is repaired under the identity keys it registered last", party)
+	}
+
+	if err := lost.RepairShare(dkg.Recovery{
+		Repair:             repair,
+		Ciphersuite:        groupKey.Ciphersuite,
+		Threshold:          groupKey.Threshold,
+		GroupPublicKey:     groupKey.GroupPublicKey,
+		VerificationShares: verificationShares,
+	}, nonceIndex); err != nil {
+		return rpc.ShareRepair{}, fmt.Errorf("failed to start repair on %s: %w", party, err)
+	}
+
+	for _, helper := range helpers {
+		if err := helper.RepairRound1(repair); err != nil {
+			return rpc.ShareRepair{}, fmt.Errorf("failed to run repair round 1 on %s: %w", helper.ID(), err)
+		}
+	}
+
+	for _, helper := range helpers {
+		if err := helper.RepairRound2(repair); err != nil {
+			return rpc.ShareRepair{}, fmt.Errorf("failed to run repair round 2 on %s: %w", helper.ID(), err)
+		}
+	}
+
+	response, err := lost.RepairFinalize(epoch)
+	if err != nil {
+		return rpc.ShareRepair{}, fmt.Errorf("failed to finalize repair on %s: %w", party, err)
+	}
+
+	if response.GroupPublicKey == nil || !response.GroupPublicKey.Equal(groupKey.GroupPublicKey) ||
+		response.VerificationShare == nil || !response.VerificationShare.Equal(verificationShares[party]) {
+		return rpc.ShareRepair{}, fmt.Errorf("party %s reported a key that does not match epoch %d", party, epoch)
+	}
+
+	c.logger.Infof("repaired share of %s in epoch %d with helpers %v", party, epoch, repair.Helpers)
+	return rpc.ShareRepair{Party: party, Epoch: epoch, Helpers: repair.Helpers}, nil
+}
+
+// chooseHelpers finds the client of the lost party and the first `threshold`
+// other share holders of the epoch that answer a ping.
+func (c *coordinator) chooseHelpers(party string, verificationShares rpc.VerificationShares, threshold uint) (partyclient.PartyClient, []partyclient.PartyClient, error) {
+	var lost partyclient.PartyClient
+	helpers := make([]partyclient.PartyClient, 0, threshold)
+
+	for _, v := range c.store.GetPartyCLients().Items {
+		if v.ID() == party {
+			lost = v
+			continue
+		}
+
+		if _, ok := verificationShares[v.ID()]; !ok || uint(len(helpers)) == threshold {
+			continue
+		}
+
+		if err := v.Ping(); err != nil {
+			c.logger.Errorf("party %s cannot help repair %s: %v", v.ID(), party, err)
+			continue
+		}
+
+		helpers = append(helpers, v)
+	}
+
+	if lost == nil {
+		return nil, nil, fmt.Errorf("party %s is not registered", party)
+	}
+
+	if uint(len(helpers)) < threshold {
+		return nil, nil, fmt.Errorf("repair needs %d helpers, %d are available", threshold, len(helpers))
+	}
+
+	return lost, helpers, nil
+}
@@ -0,0 +1,166 @@
+package repair_test
+
+import (
+	"context"
+	"fmt"
+	"frost/internal/party/mailbox"
+	partyrpc "frost/internal/party/rpc"
+	partystore "frost/internal/party/store"
+	"frost/internal/sigag"
+	client "frost/internal/sigag/sigagclient"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rosedblabs/rosedb/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// freePort finds a port nothing listens on.
+func freePort() string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).To(BeNil())
+	port := listener.Addr().(*net.TCPAddr).Port
+	Expect(listener.Close()).To(Succeed())
+	return fmt.Sprint(port)
+}
+
+// openDB opens a fresh database that is removed after the specs.
+func openDB(name string) *rosedb.DB {
+	dir, err := os.MkdirTemp("", name)
+	Expect(err).To(BeNil())
+
+	options := rosedb.DefaultOptions
+	options.DirPath = dir
+	db, err := rosedb.Open(options)
+	Expect(err).To(BeNil())
+
+	DeferCleanup(func() {
+		_ = db.Close()
+		_ = os.RemoveAll(dir)
+	})
+	return db
+}
+
+// proxy forwards to whichever party server currently stands behind a url,
+// so a party can come back at the same url after losing its disk.
+type proxy struct {
+	mu     sync.Mutex
+	target *httputil.ReverseProxy
+}
+
+func (p *proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	target := p.target
+	p.mu.Unlock()
+	target.ServeHTTP(w, r)
+}
+
+func (p *proxy) point(port string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.target = httputil.NewSingleHostReverseProxy(&url.URL{Scheme: "http", Host: "127.0.0.1:" + port})
+}
+
+var _ = Describe("Repair", Ordered, func() {
+	var (
+		logger      *logrus.Logger
+		sigagClient client.SigAgClient
+		lost        *proxy
+		lostURL     string
+	)
+
+	// spinParty runs party `id` on a port of its own and registers it at
+	// `partyURL` with the identity kept in `db`.
+	spinParty := func(id, partyURL string, db *rosedb.DB, port string) partystore.Store {
+		store := partystore.New(db)
+		key, err := store.Identity()
+		Expect(err).To(BeNil())
+
+		transport := mailbox.New(id, key, sigagClient, logger)
+		go func() {
+			_ = partyrpc.NewServer(id, key, store, logger, sigagClient, transport).Run(port)
+		}()
+
+		Eventually(func() error {
+			return sigagClient.Register(id, partyURL, key.PublicKey(), key.SigningKey(), true)
+		}).WithTimeout(5 * time.Second).Should(Succeed())
+		return store
+	}
+
+	BeforeAll(func() {
+		logger = logrus.New()
+		logger.SetOutput(io.Discard)
+		gin.SetMode(gin.TestMode)
+
+		port := freePort()
+		sigagClient = client.New(fmt.Sprintf("http://127.0.0.1:%s/", port))
+		db := openDB("repair_sigag")
+		go func() {
+			_ = sigag.New(sigag.Options{Logger: logger, Port: port}).StartSignatureAggregator(context.Background(), 2*time.Second, 100*time.Second, db, 2)
+		}()
+		Eventually(sigagClient.CheckUptime).WithTimeout(5 * time.Second).Should(BeTrue())
+
+		for _, id := range []string{"8801", "8802"} {
+			port := freePort()
+			spinParty(id, fmt.Sprintf("127.0.0.1:%s/", port), openDB("repair_party"), port)
+		}
+
+		port = freePort()
+		lost = &proxy{}
+		lost.point(port)
+		server := httptest.NewServer(lost)
+		DeferCleanup(server.Close)
+		lostURL = strings.TrimPrefix(server.URL, "http://") + "/"
+		spinParty("8803", lostURL, openDB("repair_party"), port)
+
+		Eventually(func() error {
+			_, err := sigagClient.GetGroupKey(1)
+			return err
+		}).WithTimeout(20 * time.Second).WithPolling(500 * time.Millisecond).Should(Succeed())
+	})
+
+	It("should repair a party that lost its disk under its new identity keys", func() {
+		before, err := sigagClient.GetIdentityKeys([]string{"8803"})
+		Expect(err).To(BeNil())
+
+		// the party comes back with an empty database and so a new identity
+		port := freePort()
+		lost.point(port)
+		store := spinParty("8803", lostURL, openDB("repair_party"), port)
+
+		key, err := store.Identity()
+		Expect(err).To(BeNil())
+		Expect(key.PublicKey()).ToNot(Equal(before["8803"]))
+
+		registered, err := sigagClient.GetIdentityKeys([]string{"8803"})
+		Expect(err).To(BeNil())
+		Expect(registered["8803"]).To(Equal(before["8803"]))
+
+		repair, err := sigagClient.RepairParty("8803")
+		Expect(err).To(BeNil())
+		Expect(repair.Epoch).To(Equal(uint(1)))
+		Expect(repair.Helpers).To(ConsistOf("8801", "8802"))
+
+		registered, err = sigagClient.GetIdentityKeys([]string{"8803"})
+		Expect(err).To(BeNil())
+		Expect(registered["8803"]).To(Equal(key.PublicKey()))
+
+		verificationShares, err := sigagClient.GetVerificationShares(1)
+		Expect(err).To(BeNil())
+
+		keyPackage, err := store.GetKeyPackage(1)
+		Expect(err).To(BeNil())
+		Expect(keyPackage.VerificationShare.Equal(verificationShares["8803"])).To(BeTrue())
+	})
+})
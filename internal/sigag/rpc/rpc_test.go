@@ -3,6 +3,7 @@ package rpc_test
 import (
 	"context"
 	"fmt"
+	"frost/internal/party/identity"
 	"frost/internal/sigag"
 	client "frost/internal/sigag/sigagclient"
 	"os"
@@ -51,16 +52,29 @@ var _ = Describe("Rpc", Ordered, func() {
 			Expect(isAlive).To(BeTrue())
 		})
 
+		It("should not be able to get keys of an epoch without dkg", func() {
+			_, err := SigAgClient.GetGroupKey(1)
+			Expect(err).ToNot(BeNil())
+
+			_, err = SigAgClient.GetVerificationShares(1)
+			Expect(err).ToNot(BeNil())
+		})
+
 		It("should be able to register", func() {
-			err := SigAgClient.Register("1", "127.0.0.1:8081", true)
+			key := identityKey()
+			err := SigAgClient.Register("1", "127.0.0.1:8081", key.PublicKey(), key.SigningKey(), true)
 			Expect(err).To(BeNil())
 
-			err = SigAgClient.Register("2", "127.0.0.1", true)
+			err = SigAgClient.Register("2", "127.0.0.1", key.PublicKey(), key.SigningKey(), true)
 			Expect(err).To(BeNil())
 		})
 
 		It("should not be able to register invalid participant", func() {
-			err := SigAgClient.Register("1", "127.0.0.1", true) // same user
+			key := identityKey()
+			err := SigAgClient.Register("1", "127.0.0.1", key.PublicKey(), key.SigningKey(), true) // same user
+			Expect(err).ToNot(BeNil())
+
+			err = SigAgClient.Register("3", "127.0.0.1:8083", "not a key", key.SigningKey(), true)
 			Expect(err).ToNot(BeNil())
 
 			// err = SigAgClient.Register("3", "127.1", "3", "4") // invalid ip
@@ -78,3 +92,9 @@ var _ = Describe("Rpc", Ordered, func() {
 		})
 	})
 })
+
+func identityKey() *identity.Key {
+	key, err := identity.Generate()
+	Expect(err).To(BeNil())
+	return key
+}
@@ -2,8 +2,11 @@ package rpc
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"frost/internal/party/identity"
+	"frost/pkg/frost"
 	"frost/pkg/rpc"
 	"reflect"
 
@@ -14,9 +17,11 @@ import (
 )
 
 type server struct {
-	logger *logrus.Logger
-	router *gin.Engine
-	store  Store
+	logger   *logrus.Logger
+	router   *gin.Engine
+	store    Store
+	signer   Signer
+	repairer Repairer
 }
 
 type Store interface {
@@ -25,10 +30,34 @@ type Store interface {
 	IsLocked() bool
 
 	GetEpochParties() Parties
+	GetGroupKey(epoch uint) (GroupKey, error)
+	GetVerificationShares(epoch uint) (VerificationShares, error)
+
+	PutEpochMode(mode string) error
+	GetSessionFaults(session string) ([]SessionFault, error)
+	PutDKGComplaint(complaint DKGComplaint) error
+
+	GetIdentityKeys(parties Parties) (IdentityKeys, error)
+	GetSigningKey(party string) (string, error)
+	PutMailboxMessage(message MailboxMessage) (uint64, error)
+	TakeMailboxMessages(party string, after uint64) ([]MailboxMessage, error)
+}
+
+// MaxMailboxPayload bounds the sealed payload of a mailbox message.
+const MaxMailboxPayload = 64 << 10
+
+// Signer runs a signing session with the parties of the active epoch.
+type Signer interface {
+	Sign(message []byte, bip340 *frost.BIP340) (SigningSession, error)
+}
+
+// Repairer rebuilds the share a party of the active epoch lost.
+type Repairer interface {
+	Repair(party string) (ShareRepair, error)
 }
 
-func NewServer(store Store, logger *logrus.Logger) *server {
-	return &server{store: store, router: gin.New(), logger: logger}
+func NewServer(store Store, signer Signer, repairer Repairer, logger *logrus.Logger) *server {
+	return &server{store: store, signer: signer, repairer: repairer, router: gin.New(), logger: logger}
 }
 
 func (s *server) Run(port string) error {
@@ -100,6 +129,14 @@ func (s *server) Register(_ context.Context, params *json.RawMessage) (json.RawM
 		return nil, err
 	}
 
+	if _, err := identity.ParsePublicKey(registerParty.IdentityKey); err != nil {
+		return nil, err
+	}
+
+	if _, err := identity.ParseSigningKey(registerParty.SigningKey); err != nil {
+		return nil, err
+	}
+
 	// ip := net.ParseIP(registerParty.ReportedIp)
 	// if ip == nil {
 	// 	return nil, fmt.Errorf("invalid ip address: %s", registerParty.ReportedIp)
@@ -127,3 +164,332 @@ func (s *server) GetParties(_ context.Context, params *json.RawMessage) (json.Ra
 func (s *server) GetEpochParties(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
 	return json.Marshal(s.store.GetEpochParties())
 }
+
+func (s *server) GetGroupKey(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	epoch, err := decodeEpoch(params)
+	if err != nil {
+		return nil, err
+	}
+
+	groupKey, err := s.store.GetGroupKey(epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(groupKey)
+}
+
+func (s *server) GetVerificationShares(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	epoch, err := decodeEpoch(params)
+	if err != nil {
+		return nil, err
+	}
+
+	verificationShares, err := s.store.GetVerificationShares(epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(verificationShares)
+}
+
+// SetEpochMode picks whether the following epochs generate a new group key or
+// refresh the shares of the active one.
+func (s *server) SetEpochMode(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var request EpochModeRequest
+	if err := json.Unmarshal(*params, &request); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(request); err != nil {
+		return nil, err
+	}
+
+	if request.Mode != EpochModeNewKey && request.Mode != EpochModeRefresh {
+		return nil, fmt.Errorf("unknown epoch mode %s", request.Mode)
+	}
+
+	if err := s.store.PutEpochMode(request.Mode); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("following epochs run in %s mode", request.Mode)
+	return json.Marshal(true)
+}
+
+func (s *server) Sign(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var sign SignRequest
+	if err := json.Unmarshal(*params, &sign); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(sign); err != nil {
+		return nil, err
+	}
+
+	message, err := hex.DecodeString(sign.Message)
+	if err != nil {
+		return nil, fmt.Errorf("message is not hex encoded: %w", err)
+	}
+
+	bip340, err := sign.bip340()
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.signer.Sign(message, bip340)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(session)
+}
+
+// GetSessionFaults lists the parties blamed in a signing session and the
+// evidence against them.
+func (s *server) GetSessionFaults(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var request SessionRequest
+	if err := json.Unmarshal(*params, &request); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(request); err != nil {
+		return nil, err
+	}
+
+	faults, err := s.store.GetSessionFaults(request.Session)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(faults)
+}
+
+// RepairParty has threshold parties of the active epoch rebuild the share a
+// party lost, for its existing identifier and without re-keying the group.
+func (s *server) RepairParty(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if s.store.IsLocked() {
+		return nil, fmt.Errorf("DKG in progress, cant repair shares ATM")
+	}
+
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var request RepairPartyRequest
+	if err := json.Unmarshal(*params, &request); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(request); err != nil {
+		return nil, err
+	}
+
+	repair, err := s.repairer.Repair(request.Party)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(repair)
+}
+
+// DkgComplaint records a party's complaint about the round 2 share a dealer
+// sent it. The epoch runner judges complaints once round 2 is over.
+func (s *server) DkgComplaint(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if !s.store.IsLocked() {
+		return nil, fmt.Errorf("no DKG in progress to complain about")
+	}
+
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var request DKGComplaintRequest
+	if err := json.Unmarshal(*params, &request); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(request); err != nil {
+		return nil, err
+	}
+
+	complaint := request.Complaint
+	if complaint.Accuser == complaint.Accused {
+		return nil, fmt.Errorf("party %s cannot complain about itself", complaint.Accuser)
+	}
+
+	parties := s.store.GetEpochParties()
+	for _, id := range []string{complaint.Accuser, complaint.Accused} {
+		if _, ok := parties[id]; !ok {
+			return nil, fmt.Errorf("party %s is not part of the dkg", id)
+		}
+	}
+
+	s.logger.Warnf("party %s complains about its round 2 share from %s in epoch %d: %s", complaint.Accuser, complaint.Accused, complaint.Epoch, complaint.Reason)
+	if err := s.store.PutDKGComplaint(complaint); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(true)
+}
+
+// GetIdentityKeys returns the public identity keys the parties registered.
+func (s *server) GetIdentityKeys(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var request IdentityKeysRequest
+	if err := json.Unmarshal(*params, &request); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(request); err != nil {
+		return nil, err
+	}
+
+	parties := make(Parties, len(request.Parties))
+	for _, id := range request.Parties {
+		parties[id] = ""
+	}
+
+	keys, err := s.store.GetIdentityKeys(parties)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(keys)
+}
+
+// MailboxPost leaves a message in the recipient's inbox. The message must be
+// signed by its registered sender. Sigag cannot open the payload, which is
+// sealed to the recipient.
+func (s *server) MailboxPost(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var request MailboxPostRequest
+	if err := json.Unmarshal(*params, &request); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(request); err != nil {
+		return nil, err
+	}
+
+	message := request.Message
+	if message.Method == "" || message.Sender == message.Recipient {
+		return nil, fmt.Errorf("mailbox message %q from %s to %s", message.Method, message.Sender, message.Recipient)
+	}
+
+	if len(message.Payload) > MaxMailboxPayload {
+		return nil, fmt.Errorf("mailbox payload of %d bytes exceeds %d", len(message.Payload), MaxMailboxPayload)
+	}
+
+	signingKey, err := s.store.GetSigningKey(message.Sender)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := identity.Verify(signingKey, message.SignedBytes(), message.Signature); err != nil {
+		return nil, fmt.Errorf("mailbox message from %s: %w", message.Sender, err)
+	}
+
+	if _, err := s.store.GetIdentityKeys(Parties{message.Recipient: ""}); err != nil {
+		return nil, err
+	}
+
+	seq, err := s.store.PutMailboxMessage(message)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(seq)
+}
+
+// MailboxFetch drops the messages a party has handled from its inbox and
+// returns the rest.
+func (s *server) MailboxFetch(_ context.Context, params *json.RawMessage) (json.RawMessage, error) {
+	if len(*params) == 0 {
+		return nil, fmt.Errorf("params is nil")
+	}
+
+	var request MailboxFetchRequest
+	if err := json.Unmarshal(*params, &request); err != nil {
+		return nil, err
+	}
+
+	if err := rpc.Validate(request); err != nil {
+		return nil, err
+	}
+
+	signingKey, err := s.store.GetSigningKey(request.Party)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := identity.Verify(signingKey, request.SignedBytes(), request.Signature); err != nil {
+		return nil, fmt.Errorf("mailbox fetch of %s: %w", request.Party, err)
+	}
+
+	messages, err := s.store.TakeMailboxMessages(request.Party, request.After)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(messages)
+}
+
+func decodeEpoch(params *json.RawMessage) (uint, error) {
+	if len(*params) == 0 {
+		return 0, fmt.Errorf("params is nil")
+	}
+
+	var request EpochRequest
+	if err := json.Unmarshal(*params, &request); err != nil {
+		return 0, err
+	}
+
+	if err := rpc.Validate(request); err != nil {
+		return 0, err
+	}
+
+	return request.Epoch, nil
+}
+
+// bip340 returns the BIP-340 options of the request, nil for FROST signing.
+func (sign SignRequest) bip340() (*frost.BIP340, error) {
+	merkleRoot, err := hex.DecodeString(sign.MerkleRoot)
+	if err != nil {
+		return nil, fmt.Errorf("merkle root is not hex encoded: %w", err)
+	}
+
+	if len(merkleRoot) != 0 && sign.Mode != SignModeTaproot {
+		return nil, fmt.Errorf("merkle root requires mode %s", SignModeTaproot)
+	}
+
+	switch sign.Mode {
+	case "", SignModeFROST:
+		return nil, nil
+	case SignModeBIP340:
+		return &frost.BIP340{}, nil
+	case SignModeTaproot:
+		bip340 := &frost.BIP340{Taproot: true, MerkleRoot: merkleRoot}
+		return bip340, bip340.Validate()
+	default:
+		return nil, fmt.Errorf("unknown signing mode %s", sign.Mode)
+	}
+}
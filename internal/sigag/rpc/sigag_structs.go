@@ -1,14 +1,217 @@
 package rpc
 
+import (
+	"encoding/json"
+	"fmt"
+	"frost/pkg/frost"
+	"frost/pkg/group"
+)
+
 type Parties map[string]string
 
 type RegisterParty struct {
 	Address string `json:"address,strict_check"`
 	Url     string `json:"url,strict_check"`
 
+	// IdentityKey is the hex encoded long-term X25519 key other parties
+	// seal their DKG round 2 shares and mailbox messages to.
+	IdentityKey string `json:"identity_key,strict_check"`
+
+	// SigningKey is the hex encoded Ed25519 key the party signs its mailbox
+	// messages with.
+	SigningKey string `json:"signing_key,strict_check"`
+
 	NoTLS bool `json:"no_tls"`
 }
 
 type HealthCheck struct {
 	Status string `json:"status"`
 }
+
+type EpochRequest struct {
+	Epoch uint `json:"epoch,strict_check"`
+}
+
+// Epoch modes sigag picks between when it starts an epoch.
+const (
+	// EpochModeNewKey runs a full DKG for a new group key while the parties
+	// stay the same.
+	EpochModeNewKey = "new_key"
+	// EpochModeRefresh re-randomizes the shares of the active group key.
+	EpochModeRefresh = "refresh"
+	// EpochModeReshare hands the active group key to a changed committee.
+	// Sigag runs it in place of either mode whenever the parties changed.
+	EpochModeReshare = "reshare"
+)
+
+type EpochModeRequest struct {
+	Mode string `json:"mode,strict_check"`
+}
+
+// GroupKey is the public key an epoch signs with.
+type GroupKey struct {
+	Epoch          uint           `json:"epoch"`
+	Ciphersuite    string         `json:"ciphersuite"`
+	Threshold      uint           `json:"threshold"`
+	GroupPublicKey *group.Element `json:"group_public_key"`
+
+	// RefreshedFrom is the epoch whose shares this epoch refreshed, zero for
+	// a new key.
+	RefreshedFrom uint `json:"refreshed_from,omitempty"`
+	// ResharedFrom is the epoch whose key was handed to this epoch's
+	// committee, zero unless the parties changed.
+	ResharedFrom uint `json:"reshared_from,omitempty"`
+
+	// XOnlyPublicKey is the hex encoded BIP-340 x-only form of a secp256k1
+	// group key.
+	XOnlyPublicKey string `json:"x_only_public_key,omitempty"`
+
+	// Disqualified maps the parties dropped from the epoch's DKG to the
+	// reason. They hold no share of the key.
+	Disqualified map[string]string `json:"disqualified,omitempty"`
+}
+
+// DKGComplaint is filed by a party whose round 2 share from the accused
+// dealer does not match the dealer's round 1 commitments.
+type DKGComplaint struct {
+	Epoch   uint   `json:"epoch"`
+	Accuser string `json:"accuser"`
+	Accused string `json:"accused"`
+	Reason  string `json:"reason"`
+}
+
+type DKGComplaintRequest struct {
+	Complaint DKGComplaint `json:"complaint,strict_check"`
+}
+
+// IdentityKeys maps parties to their hex encoded public identity keys.
+type IdentityKeys map[string]string
+
+type IdentityKeysRequest struct {
+	Parties []string `json:"parties,strict_check"`
+}
+
+// MailboxMessage is a message one party leaves at sigag for another, for
+// parties that cannot reach each other. Method names the recipient's rpc the
+// message is a call of, and Payload holds its params sealed to the
+// recipient's identity key. The sender signs the message, and sigag numbers
+// it in the recipient's inbox.
+type MailboxMessage struct {
+	Seq       uint64 `json:"seq,omitempty"`
+	Epoch     uint   `json:"epoch"`
+	Method    string `json:"method"`
+	Sender    string `json:"sender"`
+	Recipient string `json:"recipient"`
+
+	Nonce     []byte `json:"nonce"`
+	Payload   []byte `json:"payload"`
+	Signature []byte `json:"signature"`
+}
+
+// SignedBytes is what the sender signs: the message without its signature
+// and sequence number.
+func (m MailboxMessage) SignedBytes() []byte {
+	m.Seq, m.Signature = 0, nil
+	data, _ := json.Marshal(m)
+	return append([]byte("frost-golang/mailbox/message/v1"), data...)
+}
+
+// AAD binds the epoch, method and both parties to the sealed payload.
+func (m MailboxMessage) AAD() []byte {
+	return []byte(fmt.Sprintf("frost-golang/mailbox/epoch/%d/%s/%s/%s", m.Epoch, m.Method, m.Sender, m.Recipient))
+}
+
+type MailboxPostRequest struct {
+	Message MailboxMessage `json:"message,strict_check"`
+}
+
+// MailboxFetchRequest asks for the messages in a party's inbox numbered after
+// `After`. Messages up to `After` have been handled and are dropped. The party
+// signs the request, so nobody else can drop its messages.
+type MailboxFetchRequest struct {
+	Party     string `json:"party,strict_check"`
+	After     uint64 `json:"after"`
+	Signature []byte `json:"signature,strict_check"`
+}
+
+// SignedBytes is what the party signs.
+func (r MailboxFetchRequest) SignedBytes() []byte {
+	return []byte(fmt.Sprintf("frost-golang/mailbox/fetch/v1/%s/%d", r.Party, r.After))
+}
+
+// VerificationShares maps every party of an epoch to its public share s_i·G.
+type VerificationShares map[string]*group.Element
+
+// Signing modes accepted by the sign rpc.
+const (
+	SignModeFROST   = "frost"
+	SignModeBIP340  = "bip340"
+	SignModeTaproot = "taproot"
+)
+
+type SignRequest struct {
+	// Message is the hex encoded message to sign.
+	Message string `json:"message,strict_check"`
+
+	// Mode is one of the SignMode constants and defaults to SignModeFROST.
+	// BIP-340 modes sign a 32 byte message.
+	Mode string `json:"mode"`
+
+	// MerkleRoot is the hex encoded script tree root a taproot output
+	// commits to, empty for a key-path-only output.
+	MerkleRoot string `json:"merkle_root"`
+}
+
+type RepairPartyRequest struct {
+	Party string `json:"party,strict_check"`
+}
+
+// ShareRepair records a completed repair of a party's share.
+type ShareRepair struct {
+	Party   string   `json:"party"`
+	Epoch   uint     `json:"epoch"`
+	Helpers []string `json:"helpers"`
+}
+
+// SigningSession records a completed signature.
+type SigningSession struct {
+	ID        string          `json:"id"`
+	Epoch     uint            `json:"epoch"`
+	Message   string          `json:"message"`
+	Signers   []string        `json:"signers"`
+	Signature frost.Signature `json:"signature"`
+	Mode      string          `json:"mode"`
+
+	// PublicKey is the hex encoded key the signature verifies under: the
+	// compressed group key, or the x-only (output) key in BIP-340 modes.
+	PublicKey string `json:"public_key"`
+
+	// Encoded is the hex encoded signature, R || z per RFC 9591 or R_x || z
+	// per BIP-340.
+	Encoded string `json:"encoded"`
+
+	// Attempts counts the concurrent ROAST sessions started for the request,
+	// Excluded lists the parties blamed in any of them.
+	Attempts int      `json:"attempts"`
+	Excluded []string `json:"excluded,omitempty"`
+}
+
+// SessionFault blames a party for one attempt, a ROAST session numbered from
+// 1, of a signing request. An invalid share carries the evidence to re-run
+// the check: the share z_i, the party's verification share and the signing
+// package it answered.
+type SessionFault struct {
+	Session string `json:"session"`
+	Epoch   uint   `json:"epoch"`
+	Attempt int    `json:"attempt"`
+	Party   string `json:"party"`
+	Reason  string `json:"reason"`
+
+	Share             *group.Scalar         `json:"share,omitempty"`
+	VerificationShare *group.Element        `json:"verification_share,omitempty"`
+	Package           *frost.SigningPackage `json:"package,omitempty"`
+}
+
+type SessionRequest struct {
+	Session string `json:"session,strict_check"`
+}
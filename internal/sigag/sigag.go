@@ -5,9 +5,12 @@ import (
 	"context"
 	"frost/internal/party/partyclient"
 	"frost/internal/sigag/epoch"
+	"frost/internal/sigag/repair"
 	"frost/internal/sigag/rpc"
+	"frost/internal/sigag/signing"
 	"frost/internal/sigag/store"
 	"frost/pkg/collections"
+	"frost/pkg/frost"
 	"time"
 
 	"github.com/rosedblabs/rosedb/v2"
@@ -19,12 +22,38 @@ import (
 type sigag struct {
 	logger *logrus.Logger
 	port   string
+
+	noncePoolSize     uint
+	nonceLowWaterMark uint
+	ciphersuite       frost.Ciphersuite
+	epochMode         string
 }
 
 func New(opts Options) *sigag {
+	if opts.NoncePoolSize == 0 {
+		opts.NoncePoolSize = DefaultNoncePoolSize
+	}
+	if opts.NonceLowWaterMark == 0 {
+		opts.NonceLowWaterMark = DefaultNonceLowWaterMark
+	}
+	if opts.NonceLowWaterMark > opts.NoncePoolSize {
+		opts.NonceLowWaterMark = opts.NoncePoolSize
+	}
+	if opts.Ciphersuite == nil {
+		opts.Ciphersuite = frost.Secp256k1SHA256
+	}
+	if opts.EpochMode == "" {
+		opts.EpochMode = rpc.EpochModeNewKey
+	}
+
 	return &sigag{
 		logger: opts.Logger,
 		port:   opts.Port,
+
+		noncePoolSize:     opts.NoncePoolSize,
+		nonceLowWaterMark: opts.NonceLowWaterMark,
+		ciphersuite:       opts.Ciphersuite,
+		epochMode:         opts.EpochMode,
 	}
 }
 
@@ -42,10 +71,11 @@ func (s *sigag) StartSignatureAggregator(
 	store := store.New(peerIpList, db)
 
 	errs.Go(func() error {
-		return rpc.NewServer(store, s.logger).Run(s.port)
+		return rpc.NewServer(store, signing.NewCoordinator(store, s.logger), repair.NewCoordinator(store, s.logger), s.logger).Run(s.port)
 	})
 
-	if err := epoch.NewEpochRunner(store, intialTick, ThresholdFactor, s.logger).Run(epochDuration); err != nil {
+	noncePool := epoch.NoncePool{Size: s.noncePoolSize, LowWaterMark: s.nonceLowWaterMark}
+	if err := epoch.NewEpochRunner(store, intialTick, ThresholdFactor, noncePool, s.ciphersuite, s.epochMode, s.logger).Run(epochDuration); err != nil {
 		s.logger.Error("failed while running epoch", zap.Error(err))
 		return err
 	}
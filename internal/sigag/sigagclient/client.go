@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"frost/internal/sigag/rpc"
@@ -10,9 +11,19 @@ import (
 )
 
 type SigAgClient interface {
-	Register(id, url string, noTLS bool) error
+	Register(id, url, identityKey, signingKey string, noTLS bool) error
 	GetParticipants() (rpc.Parties, error)
 	CheckUptime() (bool, error)
+	GetGroupKey(epoch uint) (rpc.GroupKey, error)
+	GetVerificationShares(epoch uint) (rpc.VerificationShares, error)
+	Sign(message []byte, mode string, merkleRoot []byte) (rpc.SigningSession, error)
+	SetEpochMode(mode string) error
+	RepairParty(party string) (rpc.ShareRepair, error)
+	GetSessionFaults(session string) ([]rpc.SessionFault, error)
+	DKGComplaint(complaint rpc.DKGComplaint) error
+	GetIdentityKeys(parties []string) (rpc.IdentityKeys, error)
+	MailboxPost(message rpc.MailboxMessage) error
+	MailboxFetch(request rpc.MailboxFetchRequest) ([]rpc.MailboxMessage, error)
 }
 
 type client struct {
@@ -26,11 +37,13 @@ func New(url string) SigAgClient {
 	}
 }
 
-func (c *client) Register(id, url string, noTLS bool) error {
+func (c *client) Register(id, url, identityKey, signingKey string, noTLS bool) error {
 	var params = rpc.RegisterParty{
-		Address: id,
-		Url:     url,
-		NoTLS:   noTLS,
+		Address:     id,
+		Url:         url,
+		IdentityKey: identityKey,
+		SigningKey:  signingKey,
+		NoTLS:       noTLS,
 	}
 	err := c.SendRequest("register", params, nil)
 	if err != nil {
@@ -60,6 +73,109 @@ func (c *client) GetParticipants() (rpc.Parties, error) {
 	return reponse, nil
 }
 
+func (c *client) GetGroupKey(epoch uint) (rpc.GroupKey, error) {
+	var reponse rpc.GroupKey
+	err := c.SendRequest("get_group_key", rpc.EpochRequest{Epoch: epoch}, &reponse)
+	if err != nil {
+		return rpc.GroupKey{}, err
+	}
+
+	return reponse, nil
+}
+
+func (c *client) GetVerificationShares(epoch uint) (rpc.VerificationShares, error) {
+	var reponse rpc.VerificationShares
+	err := c.SendRequest("get_verification_shares", rpc.EpochRequest{Epoch: epoch}, &reponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return reponse, nil
+}
+
+func (c *client) Sign(message []byte, mode string, merkleRoot []byte) (rpc.SigningSession, error) {
+	var params = rpc.SignRequest{
+		Message:    hex.EncodeToString(message),
+		Mode:       mode,
+		MerkleRoot: hex.EncodeToString(merkleRoot),
+	}
+
+	var reponse rpc.SigningSession
+	err := c.SendRequest("sign", params, &reponse)
+	if err != nil {
+		return rpc.SigningSession{}, err
+	}
+
+	return reponse, nil
+}
+
+func (c *client) SetEpochMode(mode string) error {
+	err := c.SendRequest("set_epoch_mode", rpc.EpochModeRequest{Mode: mode}, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *client) RepairParty(party string) (rpc.ShareRepair, error) {
+	var reponse rpc.ShareRepair
+	err := c.SendRequest("repair_party", rpc.RepairPartyRequest{Party: party}, &reponse)
+	if err != nil {
+		return rpc.ShareRepair{}, err
+	}
+
+	return reponse, nil
+}
+
+func (c *client) GetSessionFaults(session string) ([]rpc.SessionFault, error) {
+	var reponse []rpc.SessionFault
+	err := c.SendRequest("get_session_faults", rpc.SessionRequest{Session: session}, &reponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return reponse, nil
+}
+
+func (c *client) DKGComplaint(complaint rpc.DKGComplaint) error {
+	err := c.SendRequest("dkg_complaint", rpc.DKGComplaintRequest{Complaint: complaint}, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *client) GetIdentityKeys(parties []string) (rpc.IdentityKeys, error) {
+	var reponse rpc.IdentityKeys
+	err := c.SendRequest("get_identity_keys", rpc.IdentityKeysRequest{Parties: parties}, &reponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return reponse, nil
+}
+
+func (c *client) MailboxPost(message rpc.MailboxMessage) error {
+	err := c.SendRequest("mailbox_post", rpc.MailboxPostRequest{Message: message}, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *client) MailboxFetch(request rpc.MailboxFetchRequest) ([]rpc.MailboxMessage, error) {
+	var reponse []rpc.MailboxMessage
+	err := c.SendRequest("mailbox_fetch", request, &reponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return reponse, nil
+}
+
 func (c *client) SendRequest(method string, params, respType interface{}) error {
 
 	paramsData, err := json.Marshal(params)
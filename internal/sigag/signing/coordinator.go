@@ -0,0 +1,175 @@
+// signing sessions driven by the signature aggregator
+package signing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"frost/internal/party/dkg"
+	"frost/internal/party/partyclient"
+	"frost/internal/sigag/rpc"
+	"frost/pkg/collections"
+	"frost/pkg/frost"
+	"frost/pkg/group"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Store interface {
+	GetPartyCLients() *collections.OrderedList[partyclient.PartyClient]
+
+	GetActiveEpoch() (uint, error)
+	GetCiphersuite(epoch uint) (frost.Ciphersuite, error)
+	GetPartiesOfEpoch(epoch uint) (rpc.Parties, error)
+	GetGroupKey(epoch uint) (rpc.GroupKey, error)
+	GetVerificationShares(epoch uint) (rpc.VerificationShares, error)
+
+	AvailableNonceCommitments(epoch uint, party string) (int, error)
+	ConsumeNonceCommitment(epoch uint, party string) (frost.NonceCommitment, error)
+
+	PutSigningSession(session rpc.SigningSession) error
+	PutSessionFault(fault rpc.SessionFault) error
+}
+
+type coordinator struct {
+	store  Store
+	logger *logrus.Logger
+
+	// timeout bounds a ROAST run.
+	timeout time.Duration
+}
+
+func NewCoordinator(store Store, logger *logrus.Logger) rpc.Signer {
+	return &coordinator{store: store, logger: logger, timeout: signingTimeout}
+}
+
+// epochKey is the public key material of the epoch a session signs in.
+type epochKey struct {
+	epoch              uint
+	cs                 frost.Ciphersuite
+	groupKey           rpc.GroupKey
+	verificationShares rpc.VerificationShares
+	partyMap           rpc.Parties
+	identifiers        map[string]*group.Scalar
+}
+
+// Sign runs ROAST over the parties of the active epoch: whenever `threshold`
+// parties are ready, that is they answered their last session with a valid
+// share and still have unused nonce commitments, a new FROST session is
+// started with them, concurrently to the sessions in flight. Every share is
+// checked against the party's verification share, parties that send an
+// invalid share or none at all are recorded as faults and never asked again,
+// and the first session that collects all of its shares is aggregated into
+// (R, z). A non-nil bip340 produces a BIP-340 signature under the x-only
+// group key or its taproot output key.
+func (c *coordinator) Sign(message []byte, bip340 *frost.BIP340) (rpc.SigningSession, error) {
+	key, err := c.activeKey()
+	if err != nil {
+		return rpc.SigningSession{}, err
+	}
+
+	id, err := sessionID()
+	if err != nil {
+		return rpc.SigningSession{}, err
+	}
+
+	session, err := c.roast(id, key, message, bip340)
+	if err != nil {
+		return rpc.SigningSession{}, fmt.Errorf("signing session %s failed: %w", id, err)
+	}
+
+	session.ID = id
+	if err := c.store.PutSigningSession(session); err != nil {
+		return rpc.SigningSession{}, err
+	}
+
+	c.logger.Infof("signing session %s in epoch %d signed by %v after %d attempts", session.ID, key.epoch, session.Signers, session.Attempts)
+	return session, nil
+}
+
+func (c *coordinator) activeKey() (epochKey, error) {
+	epoch, err := c.store.GetActiveEpoch()
+	if err != nil {
+		return epochKey{}, err
+	}
+
+	cs, err := c.store.GetCiphersuite(epoch)
+	if err != nil {
+		return epochKey{}, err
+	}
+
+	groupKey, err := c.store.GetGroupKey(epoch)
+	if err != nil {
+		return epochKey{}, err
+	}
+
+	verificationShares, err := c.store.GetVerificationShares(epoch)
+	if err != nil {
+		return epochKey{}, err
+	}
+
+	partyMap, err := c.store.GetPartiesOfEpoch(epoch)
+	if err != nil {
+		return epochKey{}, err
+	}
+
+	identifiers, err := dkg.Identifiers(cs.Group(), partyMap)
+	if err != nil {
+		return epochKey{}, err
+	}
+
+	return epochKey{
+		epoch:              epoch,
+		cs:                 cs,
+		groupKey:           groupKey,
+		verificationShares: verificationShares,
+		partyMap:           partyMap,
+		identifiers:        identifiers,
+	}, nil
+}
+
+func newSigningPackage(cs frost.Ciphersuite, message []byte, commitments []frost.SigningCommitment, bip340 *frost.BIP340) (frost.SigningPackage, error) {
+	if bip340 == nil {
+		return frost.NewSigningPackage(cs, message, commitments)
+	}
+	return frost.NewBIP340SigningPackage(cs, message, commitments, *bip340)
+}
+
+// verify checks the aggregated signature and returns the signing mode, the
+// key it verifies under and its encoding.
+func verify(cs frost.Ciphersuite, groupPublicKey *group.Element, message []byte, signature frost.Signature, bip340 *frost.BIP340) (string, []byte, []byte, error) {
+	if bip340 == nil {
+		if err := frost.Verify(cs, groupPublicKey, message, signature); err != nil {
+			return "", nil, nil, err
+		}
+
+		encoded, err := signature.Encode()
+		return rpc.SignModeFROST, groupPublicKey.Bytes(), encoded, err
+	}
+
+	output, err := bip340.OutputKey(groupPublicKey)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	if err := frost.VerifyBIP340(output.Key, message, signature); err != nil {
+		return "", nil, nil, err
+	}
+
+	mode := rpc.SignModeBIP340
+	if bip340.Taproot {
+		mode = rpc.SignModeTaproot
+	}
+
+	encoded, err := signature.EncodeBIP340()
+	return mode, frost.XOnly(output.Key), encoded, err
+}
+
+func sessionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,277 @@
+package signing_test
+
+import (
+	"errors"
+	"fmt"
+	"frost/internal/party/dkg"
+	"frost/internal/party/partyclient"
+	"frost/internal/sigag/rpc"
+	"frost/internal/sigag/signing"
+	sss "frost/pkg/SSS"
+	"frost/pkg/collections"
+	"frost/pkg/frost"
+	"frost/pkg/group"
+	"io"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+)
+
+// behaviour is how a stub party answers a signing package.
+type behaviour int
+
+const (
+	honest behaviour = iota
+	badShare
+	hanging
+)
+
+// party signs with its share of the group key, or misbehaves.
+type party struct {
+	partyclient.PartyClient
+
+	id        string
+	cs        frost.Ciphersuite
+	key       frost.KeyShare
+	behaviour behaviour
+	release   chan struct{}
+
+	mu     sync.Mutex
+	nonces map[uint]*frost.Nonce
+	calls  int
+}
+
+func (p *party) ID() string {
+	return p.id
+}
+
+func (p *party) commit() (frost.NonceCommitment, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	nonce, err := frost.NewNonce(p.cs, uint(len(p.nonces)), p.key.SigningShare)
+	if err != nil {
+		return frost.NonceCommitment{}, err
+	}
+	p.nonces[nonce.Index] = nonce
+	return nonce.Commit(), nil
+}
+
+func (p *party) Sign(_ uint, pkg frost.SigningPackage) (*group.Scalar, error) {
+	p.mu.Lock()
+	p.calls++
+	p.mu.Unlock()
+
+	if p.behaviour == hanging {
+		<-p.release
+		return nil, errors.New("hung up")
+	}
+
+	var nonce *frost.Nonce
+	for _, commitment := range pkg.Commitments {
+		if commitment.Party == p.id {
+			p.mu.Lock()
+			nonce = p.nonces[commitment.Commitment.Index]
+			p.mu.Unlock()
+		}
+	}
+	if nonce == nil {
+		return nil, fmt.Errorf("%s is no signer", p.id)
+	}
+
+	z, err := frost.Sign(p.cs, p.key, nonce, pkg)
+	if err != nil || p.behaviour == honest {
+		return z, err
+	}
+	return z.Add(group.ScalarFromInt(p.cs.Group(), 1)), nil
+}
+
+func (p *party) signed() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.calls
+}
+
+// store holds a single active epoch whose parties never run out of nonces.
+type store struct {
+	signing.Store
+
+	cs                 frost.Ciphersuite
+	parties            map[string]*party
+	clients            *collections.OrderedList[partyclient.PartyClient]
+	partyMap           rpc.Parties
+	groupKey           rpc.GroupKey
+	verificationShares rpc.VerificationShares
+
+	mu       sync.Mutex
+	faults   []rpc.SessionFault
+	sessions []rpc.SigningSession
+}
+
+func (s *store) GetPartyCLients() *collections.OrderedList[partyclient.PartyClient] {
+	return s.clients
+}
+
+func (s *store) GetActiveEpoch() (uint, error) {
+	return 1, nil
+}
+
+func (s *store) GetCiphersuite(uint) (frost.Ciphersuite, error) {
+	return s.cs, nil
+}
+
+func (s *store) GetPartiesOfEpoch(uint) (rpc.Parties, error) {
+	return s.partyMap, nil
+}
+
+func (s *store) GetGroupKey(uint) (rpc.GroupKey, error) {
+	return s.groupKey, nil
+}
+
+func (s *store) GetVerificationShares(uint) (rpc.VerificationShares, error) {
+	return s.verificationShares, nil
+}
+
+func (s *store) AvailableNonceCommitments(uint, string) (int, error) {
+	return 10, nil
+}
+
+func (s *store) ConsumeNonceCommitment(_ uint, id string) (frost.NonceCommitment, error) {
+	return s.parties[id].commit()
+}
+
+func (s *store) PutSigningSession(session rpc.SigningSession) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions = append(s.sessions, session)
+	return nil
+}
+
+func (s *store) PutSessionFault(fault rpc.SessionFault) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, fault)
+	return nil
+}
+
+// newStore deals a key of the given threshold to one party per behaviour,
+// named p1, p2, ... Hanging parties answer once release is closed.
+func newStore(cs frost.Ciphersuite, release chan struct{}, threshold uint, behaviours ...behaviour) *store {
+	g := cs.Group()
+
+	s := &store{
+		cs:                 cs,
+		parties:            make(map[string]*party),
+		clients:            collections.NewOrderedList[partyclient.PartyClient](),
+		partyMap:           make(rpc.Parties),
+		verificationShares: make(rpc.VerificationShares),
+	}
+	for i := range behaviours {
+		id := fmt.Sprintf("p%d", i+1)
+		s.partyMap[id] = "http://127.0.0.1/" + id
+	}
+
+	identifiers, err := dkg.Identifiers(g, s.partyMap)
+	Expect(err).To(BeNil())
+
+	secret, err := group.RandomScalar(g)
+	Expect(err).To(BeNil())
+	polynomial, err := sss.MakePolynomial(secret, threshold-1)
+	Expect(err).To(BeNil())
+	groupPublicKey := polynomial.Commit().PublicKey()
+	s.groupKey = rpc.GroupKey{Epoch: 1, Ciphersuite: cs.ID(), Threshold: threshold, GroupPublicKey: groupPublicKey}
+
+	for i, b := range behaviours {
+		id := fmt.Sprintf("p%d", i+1)
+		share := polynomial.Evaluate(identifiers[id])
+		p := &party{
+			id:        id,
+			cs:        cs,
+			key:       frost.KeyShare{Identifier: identifiers[id], SigningShare: share, GroupPublicKey: groupPublicKey},
+			behaviour: b,
+			release:   release,
+			nonces:    make(map[uint]*frost.Nonce),
+		}
+		s.parties[id] = p
+		s.clients.Add(p)
+		s.verificationShares[id] = group.ScalarBaseMult(share)
+	}
+
+	return s
+}
+
+var _ = Describe("Coordinator", func() {
+	for _, cs := range []frost.Ciphersuite{frost.Secp256k1SHA256, frost.Ed25519SHA512, frost.Ristretto255SHA512} {
+		cs := cs
+
+		Context("Over "+cs.ID(), func() {
+			var (
+				logger  *logrus.Logger
+				release chan struct{}
+			)
+
+			BeforeEach(func() {
+				logger = logrus.New()
+				logger.SetOutput(io.Discard)
+				release = make(chan struct{})
+			})
+
+			AfterEach(func() {
+				close(release)
+			})
+
+			It("should sign with honest parties", func() {
+				s := newStore(cs, release, 2, honest, honest, honest)
+
+				session, err := signing.NewCoordinator(s, logger).Sign([]byte("message"), nil)
+				Expect(err).To(BeNil())
+				Expect(session.Signers).To(HaveLen(2))
+				Expect(session.Excluded).To(BeEmpty())
+				Expect(frost.Verify(cs, s.groupKey.GroupPublicKey, []byte("message"), session.Signature)).To(Succeed())
+				Expect(s.faults).To(BeEmpty())
+			})
+
+			It("should blame a bad share, never ask its sender again and retry with the honest parties", func() {
+				blamed, retried := 0, 0
+				for run := 0; run < 20; run++ {
+					s := newStore(cs, release, 2, honest, honest, badShare)
+					bad := s.parties["p3"]
+
+					session, err := signing.NewCoordinator(s, logger).Sign([]byte("message"), nil)
+					Expect(err).To(BeNil())
+					Expect(frost.Verify(cs, s.groupKey.GroupPublicKey, []byte("message"), session.Signature)).To(Succeed())
+					Expect(session.Signers).ToNot(ContainElement("p3"))
+					Expect(bad.signed()).To(BeNumerically("<=", 1))
+
+					// an honest party that answered next to p3 is back in the
+					// ready set for the session that signs
+					if s.parties["p1"].signed()+s.parties["p2"].signed() > 2 {
+						retried++
+					}
+
+					// the run may end before the bad share of a session in
+					// flight is looked at
+					if len(s.faults) == 0 {
+						continue
+					}
+					blamed++
+
+					Expect(s.faults).To(HaveLen(1))
+					fault := s.faults[0]
+					Expect(fault.Party).To(Equal("p3"))
+					Expect(fault.Session).To(Equal(session.ID))
+					Expect(fault.Attempt).To(BeNumerically("<=", session.Attempts))
+					Expect(fault.Share).ToNot(BeNil())
+					Expect(fault.VerificationShare.Equal(s.verificationShares["p3"])).To(BeTrue())
+					Expect(fault.Package).ToNot(BeNil())
+					Expect(session.Excluded).To(Equal([]string{"p3"}))
+				}
+				Expect(blamed).To(BeNumerically(">", 0))
+				Expect(retried).To(BeNumerically(">", 0))
+			})
+
+		})
+	}
+})
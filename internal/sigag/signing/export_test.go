@@ -0,0 +1,13 @@
+package signing
+
+import (
+	"frost/internal/sigag/rpc"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// NewCoordinatorWithTimeout lets the tests give up on hanging parties sooner.
+func NewCoordinatorWithTimeout(store Store, logger *logrus.Logger, timeout time.Duration) rpc.Signer {
+	return &coordinator{store: store, logger: logger, timeout: timeout}
+}
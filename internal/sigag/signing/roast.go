@@ -0,0 +1,239 @@
+package signing
+
+import (
+	"encoding/hex"
+	"fmt"
+	"frost/internal/party/partyclient"
+	"frost/internal/sigag/rpc"
+	"frost/pkg/frost"
+	"frost/pkg/group"
+	mrand "math/rand"
+	"sort"
+	"time"
+)
+
+// signingTimeout bounds a ROAST run by default. With at least `threshold` honest parties
+// online a session completes long before, so it only fires when too many
+// parties hang without ever answering.
+const signingTimeout = time.Minute
+
+// attempt is one of the concurrent FROST sessions of a ROAST run.
+type attempt struct {
+	number  int
+	signers []partyclient.PartyClient
+	pkg     frost.SigningPackage
+	shares  map[string]*group.Scalar
+}
+
+// response is a signer's answer to the signing package of an attempt.
+type response struct {
+	attempt *attempt
+	signer  partyclient.PartyClient
+	share   *group.Scalar
+	err     error
+}
+
+// roast keeps starting sessions with the ready parties until one session has
+// all of its shares. A party is in at most one session at a time, so a party
+// that never answers holds up a single session and an honest party is back in
+// the ready set as soon as it answered. With at least `threshold` honest
+// parties this terminates after at most n - threshold + 1 sessions.
+func (c *coordinator) roast(id string, key epochKey, message []byte, bip340 *frost.BIP340) (rpc.SigningSession, error) {
+	threshold := key.groupKey.Threshold
+	ready := c.readyParties(key)
+	faulty := make(map[string]bool)
+
+	responses := make(chan response)
+	done := make(chan struct{})
+	defer close(done)
+
+	timeout := time.NewTimer(c.timeout)
+	defer timeout.Stop()
+
+	attempts, pending := 0, 0
+	for {
+		for uint(len(ready)) >= threshold {
+			attempts++
+			a, err := c.startAttempt(key, message, bip340, attempts, ready[:threshold])
+			if err != nil {
+				return rpc.SigningSession{}, err
+			}
+			ready = ready[threshold:]
+
+			for _, signer := range a.signers {
+				pending++
+				go func(signer partyclient.PartyClient) {
+					share, err := signer.Sign(key.epoch, a.pkg)
+					select {
+					case responses <- response{attempt: a, signer: signer, share: share, err: err}:
+					case <-done:
+					}
+				}(signer)
+			}
+		}
+
+		if pending == 0 && attempts == 0 {
+			return rpc.SigningSession{}, fmt.Errorf("only %d parties with nonce commitments, threshold is %d", len(ready), threshold)
+		}
+
+		if pending == 0 {
+			return rpc.SigningSession{}, fmt.Errorf("only %d responsive honest parties after %d attempts, threshold is %d", len(ready), attempts, threshold)
+		}
+
+		var r response
+		select {
+		case r = <-responses:
+			pending--
+		case <-timeout.C:
+			return rpc.SigningSession{}, fmt.Errorf("no session completed within %s after %d attempts", c.timeout, attempts)
+		}
+
+		if fault := c.checkShare(key, r); fault != nil {
+			fault.Session = id
+			fault.Attempt = r.attempt.number
+			if err := c.store.PutSessionFault(*fault); err != nil {
+				return rpc.SigningSession{}, err
+			}
+
+			c.logger.Errorf("signing session %s blames %s: %s", id, fault.Party, fault.Reason)
+			faulty[fault.Party] = true
+			continue
+		}
+
+		r.attempt.shares[key.identifiers[r.signer.ID()].String()] = r.share
+		if len(r.attempt.shares) == len(r.attempt.signers) {
+			session, err := finish(key, message, bip340, r.attempt)
+			if err != nil {
+				return rpc.SigningSession{}, err
+			}
+
+			session.Attempts = attempts
+			for party := range faulty {
+				session.Excluded = append(session.Excluded, party)
+			}
+			sort.Strings(session.Excluded)
+			return session, nil
+		}
+
+		if available, err := c.store.AvailableNonceCommitments(key.epoch, r.signer.ID()); err == nil && available > 0 {
+			ready = append(ready, r.signer)
+		}
+	}
+}
+
+// readyParties returns the share holders of the epoch with unused nonce
+// commitments in random order.
+func (c *coordinator) readyParties(key epochKey) []partyclient.PartyClient {
+	var ready []partyclient.PartyClient
+	for _, v := range c.store.GetPartyCLients().Items {
+		if _, ok := key.verificationShares[v.ID()]; !ok {
+			continue
+		}
+
+		available, err := c.store.AvailableNonceCommitments(key.epoch, v.ID())
+		if err != nil || available == 0 {
+			continue
+		}
+
+		ready = append(ready, v)
+	}
+
+	mrand.Shuffle(len(ready), func(i, j int) {
+		ready[i], ready[j] = ready[j], ready[i]
+	})
+
+	return ready
+}
+
+// startAttempt consumes a nonce commitment of every signer and builds the
+// signing package of a new session.
+func (c *coordinator) startAttempt(key epochKey, message []byte, bip340 *frost.BIP340, number int, signers []partyclient.PartyClient) (*attempt, error) {
+	commitments := make([]frost.SigningCommitment, 0, len(signers))
+	for _, signer := range signers {
+		commitment, err := c.store.ConsumeNonceCommitment(key.epoch, signer.ID())
+		if err != nil {
+			return nil, err
+		}
+
+		commitments = append(commitments, frost.SigningCommitment{
+			Party:      signer.ID(),
+			Identifier: key.identifiers[signer.ID()],
+			Commitment: commitment,
+		})
+	}
+
+	pkg, err := newSigningPackage(key.cs, message, commitments, bip340)
+	if err != nil {
+		return nil, err
+	}
+
+	return &attempt{
+		number:  number,
+		signers: append([]partyclient.PartyClient(nil), signers...),
+		pkg:     pkg,
+		shares:  make(map[string]*group.Scalar, len(signers)),
+	}, nil
+}
+
+// checkShare returns the fault of a signer that did not answer with a share
+// that verifies against its verification share, or nil.
+func (c *coordinator) checkShare(key epochKey, r response) *rpc.SessionFault {
+	if r.err != nil {
+		return &rpc.SessionFault{
+			Epoch:  key.epoch,
+			Party:  r.signer.ID(),
+			Reason: fmt.Sprintf("no signature share: %v", r.err),
+		}
+	}
+
+	if r.share == nil {
+		return &rpc.SessionFault{
+			Epoch:  key.epoch,
+			Party:  r.signer.ID(),
+			Reason: "empty signature share",
+		}
+	}
+
+	identifier := key.identifiers[r.signer.ID()]
+	verificationShare := key.verificationShares[r.signer.ID()]
+	if err := frost.VerifySignatureShare(key.cs, identifier, verificationShare, r.share, key.groupKey.GroupPublicKey, r.attempt.pkg); err != nil {
+		pkg := r.attempt.pkg
+		return &rpc.SessionFault{
+			Epoch:             key.epoch,
+			Party:             r.signer.ID(),
+			Reason:            err.Error(),
+			Share:             r.share,
+			VerificationShare: verificationShare,
+			Package:           &pkg,
+		}
+	}
+
+	return nil
+}
+
+// finish aggregates the verified shares of a complete session.
+func finish(key epochKey, message []byte, bip340 *frost.BIP340, a *attempt) (rpc.SigningSession, error) {
+	signature, err := frost.Aggregate(key.cs, key.groupKey.GroupPublicKey, a.pkg, a.shares)
+	if err != nil {
+		return rpc.SigningSession{}, err
+	}
+
+	mode, publicKey, encoded, err := verify(key.cs, key.groupKey.GroupPublicKey, message, signature, bip340)
+	if err != nil {
+		return rpc.SigningSession{}, err
+	}
+
+	session := rpc.SigningSession{
+		Epoch:     key.epoch,
+		Message:   hex.EncodeToString(message),
+		Signature: signature,
+		Mode:      mode,
+		PublicKey: hex.EncodeToString(publicKey),
+		Encoded:   hex.EncodeToString(encoded),
+	}
+	for _, signer := range a.signers {
+		session.Signers = append(session.Signers, signer.ID())
+	}
+
+	return session, nil
+}
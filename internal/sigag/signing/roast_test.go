@@ -0,0 +1,76 @@
+package signing_test
+
+import (
+	"frost/internal/sigag/signing"
+	"frost/pkg/frost"
+	"io"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+)
+
+var _ = Describe("ROAST", func() {
+	for _, cs := range []frost.Ciphersuite{frost.Secp256k1SHA256, frost.Ed25519SHA512, frost.Ristretto255SHA512} {
+		cs := cs
+
+		Context("Over "+cs.ID(), func() {
+			var (
+				logger  *logrus.Logger
+				release chan struct{}
+			)
+
+			BeforeEach(func() {
+				logger = logrus.New()
+				logger.SetOutput(io.Discard)
+				release = make(chan struct{})
+			})
+
+			AfterEach(func() {
+				close(release)
+			})
+
+			It("should finish around a party that never answers", func() {
+				for run := 0; run < 10; run++ {
+					s := newStore(cs, release, 2, honest, hanging, honest)
+
+					session, err := signing.NewCoordinator(s, logger).Sign([]byte("message"), nil)
+					Expect(err).To(BeNil())
+					Expect(session.Signers).To(ConsistOf("p1", "p3"))
+					Expect(s.parties["p2"].signed()).To(BeNumerically("<=", 1))
+				}
+			})
+
+			It("should fail once fewer than threshold honest parties remain", func() {
+				s := newStore(cs, release, 2, honest, badShare, badShare)
+
+				_, err := signing.NewCoordinator(s, logger).Sign([]byte("message"), nil)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(ContainSubstring("only 1 responsive honest parties"))
+
+				Expect(s.faults).To(HaveLen(2))
+				var blamed []string
+				for _, fault := range s.faults {
+					blamed = append(blamed, fault.Party)
+				}
+				Expect(blamed).To(ConsistOf("p2", "p3"))
+				Expect(s.parties["p2"].signed()).To(Equal(1))
+				Expect(s.parties["p3"].signed()).To(Equal(1))
+				Expect(s.sessions).To(BeEmpty())
+			})
+
+			It("should give up when the parties in flight never answer", func() {
+				s := newStore(cs, release, 2, honest, hanging)
+
+				start := time.Now()
+				_, err := signing.NewCoordinatorWithTimeout(s, logger, 100*time.Millisecond).Sign([]byte("message"), nil)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(ContainSubstring("no session completed within 100ms"))
+				Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+				Expect(s.faults).To(BeEmpty())
+				Expect(s.sessions).To(BeEmpty())
+			})
+		})
+	}
+})
@@ -1,11 +1,17 @@
 package store
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"frost/internal/party/partyclient"
 	"frost/internal/sigag/epoch"
+	"frost/internal/sigag/repair"
 	"frost/internal/sigag/rpc"
+	"frost/internal/sigag/signing"
 	"frost/pkg/collections"
+	"frost/pkg/frost"
+	"strconv"
 	"sync"
 
 	"github.com/rosedblabs/rosedb/v2"
@@ -15,13 +21,144 @@ var containsID = func(item, element partyclient.PartyClient) bool {
 	return item.ID() == element.ID()
 }
 
+// MaxInboxSize bounds the messages waiting in a party's inbox.
+const MaxInboxSize = 4096
+
+// pooledCommitment is a party's nonce commitment held for future signing
+// sessions. Consumed commitments are kept so an index is never handed out twice.
+type pooledCommitment struct {
+	frost.NonceCommitment
+	Consumed bool `json:"consumed"`
+}
+
+// registration is what it takes to reach a registered party again after a
+// restart. The url carries its scheme.
+type registration struct {
+	Address string `json:"address"`
+	Url     string `json:"url"`
+}
+
 type store struct {
 	peerIpList *collections.OrderedList[partyclient.PartyClient]
-	locked     bool
 	mu         sync.RWMutex
 	db         *rosedb.DB
 }
 
+// PutEpochState implements epoch.Store. The state is kept per epoch as well,
+// and an epoch older than the latest one is refused, so an epoch number is
+// never handed out twice.
+func (s *store) PutEpochState(state epoch.State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	latest, err := s.epochState()
+	if err != nil {
+		return err
+	}
+
+	if state.Epoch < latest.Epoch {
+		return fmt.Errorf("epoch %d is older than epoch %d", state.Epoch, latest.Epoch)
+	}
+
+	value, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	batch := s.db.NewBatch(rosedb.DefaultBatchOptions)
+	if err := batch.Put([]byte(fmt.Sprintf("EPOCH_%d_STATE", state.Epoch)), value); err != nil {
+		_ = batch.Rollback()
+		return err
+	}
+	if err := batch.Put([]byte("EPOCH_STATE"), value); err != nil {
+		_ = batch.Rollback()
+		return err
+	}
+
+	return batch.Commit()
+}
+
+// GetEpochState implements epoch.Store. Before the first epoch the state is
+// empty.
+func (s *store) GetEpochState() (epoch.State, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.epochState()
+}
+
+func (s *store) epochState() (epoch.State, error) {
+	var state epoch.State
+	data, err := s.db.Get([]byte("EPOCH_STATE"))
+	if errors.Is(err, rosedb.ErrKeyNotFound) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, err
+	}
+
+	return state, nil
+}
+
+// RestoreParties implements epoch.Store. It lists the parties registered
+// before a restart again, a party that is gone drops out when the next
+// epoch is announced.
+func (s *store) RestoreParties() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	registrations, err := s.registrations()
+	if err != nil {
+		return err
+	}
+
+	for _, registered := range registrations {
+		participant := partyclient.NewFromURL(registered.Address, registered.Url)
+		if !s.peerIpList.Contains(participant, containsID) {
+			s.peerIpList.Add(participant)
+		}
+	}
+
+	return nil
+}
+
+func (s *store) registrations() ([]registration, error) {
+	registrations := []registration{}
+	data, err := s.db.Get([]byte("REGISTRATIONS"))
+	if errors.Is(err, rosedb.ErrKeyNotFound) {
+		return registrations, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &registrations); err != nil {
+		return nil, err
+	}
+
+	return registrations, nil
+}
+
+// putRegistrations records the parties currently registered.
+func (s *store) putRegistrations() error {
+	registrations := make([]registration, 0, len(s.peerIpList.Items))
+	for _, v := range s.peerIpList.Items {
+		id, url := v.Locate()
+		registrations = append(registrations, registration{Address: id, Url: url})
+	}
+
+	value, err := json.Marshal(registrations)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Put([]byte("REGISTRATIONS"), value)
+}
+
 // PutThreshold implements Store.
 func (s *store) PutThreshold(threshold uint, epoch uint) error {
 	s.mu.Lock()
@@ -34,6 +171,51 @@ func (s *store) PutThreshold(threshold uint, epoch uint) error {
 	return nil
 }
 
+// PutCiphersuite implements Store.
+func (s *store) PutCiphersuite(ciphersuite string, epoch uint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.db.Put([]byte(fmt.Sprintf("EPOCH_%d_CIPHERSUITE", epoch)), []byte(ciphersuite))
+}
+
+// GetCiphersuite returns the ciphersuite an epoch's keys were generated for.
+func (s *store) GetCiphersuite(epoch uint) (frost.Ciphersuite, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, err := s.db.Get([]byte(fmt.Sprintf("EPOCH_%d_CIPHERSUITE", epoch)))
+	if errors.Is(err, rosedb.ErrKeyNotFound) {
+		return nil, fmt.Errorf("no ciphersuite for epoch %d", epoch)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return frost.CiphersuiteByID(string(id))
+}
+
+// PutEpochMode implements Store.
+func (s *store) PutEpochMode(mode string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.db.Put([]byte("EPOCH_MODE"), []byte(mode))
+}
+
+// GetEpochMode implements Store. It is empty until a mode was picked.
+func (s *store) GetEpochMode() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	mode, err := s.db.Get([]byte("EPOCH_MODE"))
+	if err != nil {
+		return ""
+	}
+
+	return string(mode)
+}
+
 // RemoveParty implements Store.
 func (s *store) RemoveParty(item partyclient.PartyClient) error {
 	s.mu.Lock()
@@ -43,7 +225,7 @@ func (s *store) RemoveParty(item partyclient.PartyClient) error {
 		return err
 	}
 
-	return nil
+	return s.putRegistrations()
 }
 
 // GetEpochParties implements Store.
@@ -52,57 +234,389 @@ func (s *store) GetEpochParties() rpc.Parties {
 	defer s.mu.RUnlock()
 
 	Parties := make(rpc.Parties)
-	s.db.Ascend(func(k []byte, v []byte) (bool, error) {
-		Parties[string(k)] = string(v)
-		return true, nil
-	})
+	epoch, err := s.db.Get([]byte("LATEST_EPOCH"))
+	if err != nil {
+		return Parties
+	}
+
+	value, err := s.db.Get([]byte(fmt.Sprintf("EPOCH_%s_PARTIES", epoch)))
+	if err != nil {
+		return Parties
+	}
 
+	_ = json.Unmarshal(value, &Parties)
 	return Parties
 }
 
 // PutParties implements Store.
-func (s *store) PutParties(parties rpc.Parties) error {
+func (s *store) PutParties(parties rpc.Parties, epoch uint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, err := json.Marshal(parties)
+	if err != nil {
+		return err
+	}
+
 	batch := s.db.NewBatch(rosedb.DefaultBatchOptions)
+	if err := batch.Put([]byte(fmt.Sprintf("EPOCH_%d_PARTIES", epoch)), value); err != nil {
+		_ = batch.Rollback()
+		return err
+	}
+	if err := batch.Put([]byte("LATEST_EPOCH"), []byte(fmt.Sprintf("%d", epoch))); err != nil {
+		_ = batch.Rollback()
+		return err
+	}
 
-	for id, url := range parties {
-		if err := batch.Put([]byte(id), []byte(url)); err != nil {
-			return err
-		}
+	return batch.Commit()
+}
+
+// PutGroupKey implements Store.
+func (s *store) PutGroupKey(groupKey rpc.GroupKey, verificationShares rpc.VerificationShares) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, err := json.Marshal(groupKey)
+	if err != nil {
+		return err
+	}
+
+	shares, err := json.Marshal(verificationShares)
+	if err != nil {
+		return err
+	}
+
+	batch := s.db.NewBatch(rosedb.DefaultBatchOptions)
+	if err := batch.Put([]byte(fmt.Sprintf("EPOCH_%d_GROUP_KEY", groupKey.Epoch)), key); err != nil {
+		_ = batch.Rollback()
+		return err
+	}
+	if err := batch.Put([]byte(fmt.Sprintf("EPOCH_%d_VERIFICATION_SHARES", groupKey.Epoch)), shares); err != nil {
+		_ = batch.Rollback()
+		return err
+	}
+	if err := batch.Put([]byte("ACTIVE_EPOCH"), []byte(fmt.Sprintf("%d", groupKey.Epoch))); err != nil {
+		_ = batch.Rollback()
+		return err
 	}
 
 	return batch.Commit()
 }
 
-// GetPartyCLients implements Store.
-func (s *store) GetPartyCLients() *collections.OrderedList[partyclient.PartyClient] {
+// GetActiveEpoch returns the latest epoch with a published group key.
+func (s *store) GetActiveEpoch() (uint, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	return s.peerIpList
+	var epoch uint
+	if err := s.get("ACTIVE_EPOCH", &epoch); err != nil {
+		return 0, fmt.Errorf("no active epoch: %w", err)
+	}
+
+	return epoch, nil
 }
 
-// IsLocked implements Store.
-func (s *store) IsLocked() bool {
+// GetPartiesOfEpoch returns the parties that took part in an epoch's DKG.
+func (s *store) GetPartiesOfEpoch(epoch uint) (rpc.Parties, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var parties rpc.Parties
+	if err := s.get(fmt.Sprintf("EPOCH_%d_PARTIES", epoch), &parties); err != nil {
+		return nil, err
+	}
+
+	return parties, nil
+}
+
+// PutSigningSession records a completed signing session.
+func (s *store) PutSigningSession(session rpc.SigningSession) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Put([]byte(fmt.Sprintf("SESSION_%s", session.ID)), value)
+}
+
+// PutSessionFault appends a fault to the faults of its signing session.
+func (s *store) PutSessionFault(fault rpc.SessionFault) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	faults, err := s.sessionFaults(fault.Session)
+	if err != nil {
+		return err
+	}
+
+	value, err := json.Marshal(append(faults, fault))
+	if err != nil {
+		return err
+	}
+
+	return s.db.Put([]byte(fmt.Sprintf("SESSION_%s_FAULTS", fault.Session)), value)
+}
+
+// GetSessionFaults implements Store. A session without faults has none recorded.
+func (s *store) GetSessionFaults(session string) ([]rpc.SessionFault, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.sessionFaults(session)
+}
+
+func (s *store) sessionFaults(session string) ([]rpc.SessionFault, error) {
+	faults := []rpc.SessionFault{}
+	data, err := s.db.Get([]byte(fmt.Sprintf("SESSION_%s_FAULTS", session)))
+	if errors.Is(err, rosedb.ErrKeyNotFound) {
+		return faults, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &faults); err != nil {
+		return nil, err
+	}
+
+	return faults, nil
+}
+
+// PutDKGComplaint records a complaint of the epoch's DKG. A party complains
+// about a dealer once, however often the dealer retried the share.
+func (s *store) PutDKGComplaint(complaint rpc.DKGComplaint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	complaints, err := s.dkgComplaints(complaint.Epoch)
+	if err != nil {
+		return err
+	}
+
+	for _, filed := range complaints {
+		if filed.Accuser == complaint.Accuser && filed.Accused == complaint.Accused {
+			return nil
+		}
+	}
+
+	value, err := json.Marshal(append(complaints, complaint))
+	if err != nil {
+		return err
+	}
+
+	return s.db.Put([]byte(fmt.Sprintf("EPOCH_%d_COMPLAINTS", complaint.Epoch)), value)
+}
+
+// GetDKGComplaints implements Store.
+func (s *store) GetDKGComplaints(epoch uint) ([]rpc.DKGComplaint, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.dkgComplaints(epoch)
+}
+
+func (s *store) dkgComplaints(epoch uint) ([]rpc.DKGComplaint, error) {
+	complaints := []rpc.DKGComplaint{}
+	data, err := s.db.Get([]byte(fmt.Sprintf("EPOCH_%d_COMPLAINTS", epoch)))
+	if errors.Is(err, rosedb.ErrKeyNotFound) {
+		return complaints, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &complaints); err != nil {
+		return nil, err
+	}
+
+	return complaints, nil
+}
+
+// GetGroupKey implements Store.
+func (s *store) GetGroupKey(epoch uint) (rpc.GroupKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var groupKey rpc.GroupKey
+	if err := s.get(fmt.Sprintf("EPOCH_%d_GROUP_KEY", epoch), &groupKey); err != nil {
+		return rpc.GroupKey{}, err
+	}
+
+	return groupKey, nil
+}
+
+// GetVerificationShares implements Store.
+func (s *store) GetVerificationShares(epoch uint) (rpc.VerificationShares, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	return s.locked
+	var verificationShares rpc.VerificationShares
+	if err := s.get(fmt.Sprintf("EPOCH_%d_VERIFICATION_SHARES", epoch), &verificationShares); err != nil {
+		return nil, err
+	}
+
+	return verificationShares, nil
 }
 
-// Lock implements epoch.Store.
-func (s *store) Lock() {
+// PutNonceCommitments implements Store.
+func (s *store) PutNonceCommitments(epoch uint, party string, commitments []frost.NonceCommitment) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.locked = true
+	pool, err := s.noncePool(epoch, party)
+	if err != nil {
+		return err
+	}
+
+	for _, commitment := range commitments {
+		if commitment.Hiding == nil || commitment.Binding == nil || commitment.Hiding.IsIdentity() || commitment.Binding.IsIdentity() {
+			return fmt.Errorf("invalid nonce commitment %d from %s", commitment.Index, party)
+		}
+
+		if len(pool) > 0 && commitment.Index <= pool[len(pool)-1].Index {
+			return fmt.Errorf("nonce commitment %d from %s reuses an index", commitment.Index, party)
+		}
+
+		pool = append(pool, pooledCommitment{NonceCommitment: commitment})
+	}
+
+	return s.putNoncePool(epoch, party, pool)
+}
+
+// AvailableNonceCommitments implements Store.
+func (s *store) AvailableNonceCommitments(epoch uint, party string) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	pool, err := s.noncePool(epoch, party)
+	if err != nil {
+		return 0, err
+	}
+
+	available := 0
+	for _, commitment := range pool {
+		if !commitment.Consumed {
+			available++
+		}
+	}
+
+	return available, nil
+}
+
+// ConsumeNonceCommitment hands out the oldest unused commitment of a party and
+// marks it consumed, so it is never used by a second signing session.
+func (s *store) ConsumeNonceCommitment(epoch uint, party string) (frost.NonceCommitment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pool, err := s.noncePool(epoch, party)
+	if err != nil {
+		return frost.NonceCommitment{}, err
+	}
+
+	for i := range pool {
+		if pool[i].Consumed {
+			continue
+		}
+
+		pool[i].Consumed = true
+		if err := s.putNoncePool(epoch, party, pool); err != nil {
+			return frost.NonceCommitment{}, err
+		}
+
+		return pool[i].NonceCommitment, nil
+	}
+
+	return frost.NonceCommitment{}, fmt.Errorf("no nonce commitments left for %s in epoch %d", party, epoch)
 }
 
-// UnLock implements epoch.Store.
-func (s *store) UnLock() {
+// RetireNonceCommitments marks every commitment of a party consumed, after
+// it lost the nonces behind them, and returns the first index it may reuse.
+func (s *store) RetireNonceCommitments(epoch uint, party string) (uint, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.locked = false
+	pool, err := s.noncePool(epoch, party)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(pool) == 0 {
+		return 0, nil
+	}
+
+	for i := range pool {
+		pool[i].Consumed = true
+	}
+
+	if err := s.putNoncePool(epoch, party, pool); err != nil {
+		return 0, err
+	}
+
+	return pool[len(pool)-1].Index + 1, nil
+}
+
+func (s *store) noncePool(epoch uint, party string) ([]pooledCommitment, error) {
+	var pool []pooledCommitment
+	data, err := s.db.Get([]byte(fmt.Sprintf("EPOCH_%d_PARTY_%s_NONCES", epoch, party)))
+	if errors.Is(err, rosedb.ErrKeyNotFound) {
+		return pool, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &pool); err != nil {
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+func (s *store) putNoncePool(epoch uint, party string, pool []pooledCommitment) error {
+	data, err := json.Marshal(pool)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Put([]byte(fmt.Sprintf("EPOCH_%d_PARTY_%s_NONCES", epoch, party)), data)
+}
+
+// get decodes the json value stored under key.
+func (s *store) get(key string, value interface{}) error {
+	data, err := s.db.Get([]byte(key))
+	if errors.Is(err, rosedb.ErrKeyNotFound) {
+		return fmt.Errorf("%s not found", key)
+	}
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, value)
+}
+
+// GetPartyCLients implements Store.
+func (s *store) GetPartyCLients() *collections.OrderedList[partyclient.PartyClient] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.peerIpList
+}
+
+// IsLocked implements Store. The system is locked while an epoch's dkg runs.
+func (s *store) IsLocked() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	state, err := s.epochState()
+	if err != nil {
+		return true
+	}
+
+	return state.Phase.Locked()
 }
 
 // AddParticipant implements rpc.Store.
@@ -113,6 +627,17 @@ func (s *store) AddParticipant(party rpc.RegisterParty) error {
 	participant := partyclient.New(party.Address, party.Url, party.NoTLS)
 
 	if s.peerIpList.Contains(participant, containsID) {
+		// a party restarting at the same url, e.g. to repair a lost share
+		_, url := participant.Locate()
+		for _, v := range s.peerIpList.Items {
+			if _, registered := v.Locate(); v.ID() == participant.ID() && registered == url {
+				if err := participant.Ping(); err != nil {
+					return err
+				}
+				// other identity keys wait until the party is repaired
+				return s.putIdentityKey(party)
+			}
+		}
 		return fmt.Errorf("address already registered")
 	}
 
@@ -120,8 +645,226 @@ func (s *store) AddParticipant(party rpc.RegisterParty) error {
 		return err
 	}
 
+	if err := s.putIdentityKey(party); err != nil {
+		return err
+	}
+
 	s.peerIpList.Add(participant)
-	return nil
+	return s.putRegistrations()
+}
+
+// putIdentityKey stores the keys a party registered with. Other parties seal
+// their shares and mailbox messages to these keys, so once an address has
+// keys a registration with other keys does not take the party over. The
+// other keys are held until the operator repairs the party, which a party
+// that lost its disk needs anyway.
+func (s *store) putIdentityKey(party rpc.RegisterParty) error {
+	identityKey := []byte(fmt.Sprintf("PARTY_%s_IDENTITY_KEY", party.Address))
+	signingKey := []byte(fmt.Sprintf("PARTY_%s_SIGNING_KEY", party.Address))
+
+	registered, err := s.db.Get(identityKey)
+	if err == nil {
+		registeredSigning, err := s.db.Get(signingKey)
+		if err != nil {
+			return err
+		}
+
+		if string(registered) == party.IdentityKey && string(registeredSigning) == party.SigningKey {
+			return nil
+		}
+
+		identityKey = []byte(fmt.Sprintf("PARTY_%s_NEW_IDENTITY_KEY", party.Address))
+		signingKey = []byte(fmt.Sprintf("PARTY_%s_NEW_SIGNING_KEY", party.Address))
+	} else if !errors.Is(err, rosedb.ErrKeyNotFound) {
+		return err
+	}
+
+	batch := s.db.NewBatch(rosedb.DefaultBatchOptions)
+	if err := batch.Put(identityKey, []byte(party.IdentityKey)); err != nil {
+		_ = batch.Rollback()
+		return err
+	}
+	if err := batch.Put(signingKey, []byte(party.SigningKey)); err != nil {
+		_ = batch.Rollback()
+		return err
+	}
+
+	return batch.Commit()
+}
+
+// RenewIdentityKey implements repair.Store. The keys `party` last registered
+// with in place of its own become its keys, telling whether there were any.
+func (s *store) RenewIdentityKey(party string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	newIdentityKey := []byte(fmt.Sprintf("PARTY_%s_NEW_IDENTITY_KEY", party))
+	newSigningKey := []byte(fmt.Sprintf("PARTY_%s_NEW_SIGNING_KEY", party))
+
+	identityKey, err := s.db.Get(newIdentityKey)
+	if errors.Is(err, rosedb.ErrKeyNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	signingKey, err := s.db.Get(newSigningKey)
+	if err != nil {
+		return false, err
+	}
+
+	batch := s.db.NewBatch(rosedb.DefaultBatchOptions)
+	if err := batch.Put([]byte(fmt.Sprintf("PARTY_%s_IDENTITY_KEY", party)), identityKey); err != nil {
+		_ = batch.Rollback()
+		return false, err
+	}
+	if err := batch.Put([]byte(fmt.Sprintf("PARTY_%s_SIGNING_KEY", party)), signingKey); err != nil {
+		_ = batch.Rollback()
+		return false, err
+	}
+	if err := batch.Delete(newIdentityKey); err != nil {
+		_ = batch.Rollback()
+		return false, err
+	}
+	if err := batch.Delete(newSigningKey); err != nil {
+		_ = batch.Rollback()
+		return false, err
+	}
+
+	return true, batch.Commit()
+}
+
+// GetSigningKey implements rpc.Store.
+func (s *store) GetSigningKey(party string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, err := s.db.Get([]byte(fmt.Sprintf("PARTY_%s_SIGNING_KEY", party)))
+	if errors.Is(err, rosedb.ErrKeyNotFound) {
+		return "", fmt.Errorf("party %s registered no signing key", party)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return string(key), nil
+}
+
+// GetIdentityKeys implements Store.
+func (s *store) GetIdentityKeys(parties rpc.Parties) (rpc.IdentityKeys, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make(rpc.IdentityKeys, len(parties))
+	for id := range parties {
+		key, err := s.db.Get([]byte(fmt.Sprintf("PARTY_%s_IDENTITY_KEY", id)))
+		if errors.Is(err, rosedb.ErrKeyNotFound) {
+			return nil, fmt.Errorf("party %s registered no identity key", id)
+		}
+		if err != nil {
+			return nil, err
+		}
+		keys[id] = string(key)
+	}
+
+	return keys, nil
+}
+
+// PutMailboxMessage implements rpc.Store. The message is numbered after the
+// last message ever put in the recipient's inbox, so numbers are never reused
+// after messages were dropped.
+func (s *store) PutMailboxMessage(message rpc.MailboxMessage) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	inbox, err := s.inbox(message.Recipient)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(inbox) >= MaxInboxSize {
+		return 0, fmt.Errorf("inbox of %s is full", message.Recipient)
+	}
+
+	var seq uint64
+	last, err := s.db.Get([]byte(fmt.Sprintf("MAILBOX_%s_SEQ", message.Recipient)))
+	if err != nil && !errors.Is(err, rosedb.ErrKeyNotFound) {
+		return 0, err
+	}
+	if err == nil {
+		if seq, err = strconv.ParseUint(string(last), 10, 64); err != nil {
+			return 0, err
+		}
+	}
+	seq++
+	message.Seq = seq
+
+	value, err := json.Marshal(append(inbox, message))
+	if err != nil {
+		return 0, err
+	}
+
+	batch := s.db.NewBatch(rosedb.DefaultBatchOptions)
+	if err := batch.Put([]byte(fmt.Sprintf("MAILBOX_%s_SEQ", message.Recipient)), []byte(fmt.Sprintf("%d", seq))); err != nil {
+		_ = batch.Rollback()
+		return 0, err
+	}
+	if err := batch.Put([]byte(fmt.Sprintf("MAILBOX_%s", message.Recipient)), value); err != nil {
+		_ = batch.Rollback()
+		return 0, err
+	}
+
+	return seq, batch.Commit()
+}
+
+// TakeMailboxMessages implements rpc.Store. Messages numbered up to `after`
+// are dropped from the inbox, the rest returned in order.
+func (s *store) TakeMailboxMessages(party string, after uint64) ([]rpc.MailboxMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	inbox, err := s.inbox(party)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := []rpc.MailboxMessage{}
+	for _, message := range inbox {
+		if message.Seq > after {
+			kept = append(kept, message)
+		}
+	}
+
+	if len(kept) < len(inbox) {
+		value, err := json.Marshal(kept)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.db.Put([]byte(fmt.Sprintf("MAILBOX_%s", party)), value); err != nil {
+			return nil, err
+		}
+	}
+
+	return kept, nil
+}
+
+func (s *store) inbox(party string) ([]rpc.MailboxMessage, error) {
+	inbox := []rpc.MailboxMessage{}
+	data, err := s.db.Get([]byte(fmt.Sprintf("MAILBOX_%s", party)))
+	if errors.Is(err, rosedb.ErrKeyNotFound) {
+		return inbox, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &inbox); err != nil {
+		return nil, err
+	}
+
+	return inbox, nil
 }
 
 // GetParties implements rpc.Store.
@@ -141,12 +884,13 @@ func (s *store) GetParties() rpc.Parties {
 type Store interface {
 	rpc.Store
 	epoch.Store
+	signing.Store
+	repair.Store
 }
 
 func New(peerIpList *collections.OrderedList[partyclient.PartyClient], db *rosedb.DB) Store {
 	return &store{
 		peerIpList: peerIpList,
-		locked:     false,
 		mu:         sync.RWMutex{},
 		db:         db,
 	}
@@ -0,0 +1,114 @@
+package store_test
+
+import (
+	"encoding/json"
+	"frost/internal/party/identity"
+	"frost/internal/party/partyclient"
+	"frost/internal/sigag/rpc"
+	"frost/internal/sigag/store"
+	"frost/pkg/collections"
+	"frost/pkg/types"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rosedblabs/rosedb/v2"
+)
+
+// openStore opens a store over a fresh database that is removed after the spec.
+func openStore() store.Store {
+	dir, err := os.MkdirTemp("", "sigag_store")
+	Expect(err).To(BeNil())
+
+	options := rosedb.DefaultOptions
+	options.DirPath = dir
+	db, err := rosedb.Open(options)
+	Expect(err).To(BeNil())
+
+	DeferCleanup(func() {
+		_ = db.Close()
+		_ = os.RemoveAll(dir)
+	})
+
+	return store.New(collections.NewOrderedList[partyclient.PartyClient](), db)
+}
+
+// registration of a party that answers pings at `url`, with fresh identity keys.
+func registration(address, url string) rpc.RegisterParty {
+	key, err := identity.Generate()
+	Expect(err).To(BeNil())
+
+	return rpc.RegisterParty{
+		Address:     address,
+		Url:         strings.TrimPrefix(url, "http://"),
+		IdentityKey: key.PublicKey(),
+		SigningKey:  key.SigningKey(),
+		NoTLS:       true,
+	}
+}
+
+var _ = Describe("Store", func() {
+	Context("While registering parties", func() {
+		var (
+			s     store.Store
+			party *httptest.Server
+		)
+
+		BeforeEach(func() {
+			s = openStore()
+			party = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_ = json.NewEncoder(w).Encode(types.JSONResponse{JSONRPC: types.Version, Result: json.RawMessage(`{"message":"pong"}`), ID: 1})
+			}))
+			DeferCleanup(party.Close)
+		})
+
+		It("should keep the identity keys of a party that registers again", func() {
+			first := registration("8801", party.URL)
+			Expect(s.AddParticipant(first)).To(Succeed())
+			Expect(s.AddParticipant(first)).To(Succeed())
+
+			keys, err := s.GetIdentityKeys(rpc.Parties{"8801": party.URL})
+			Expect(err).To(BeNil())
+			Expect(keys["8801"]).To(Equal(first.IdentityKey))
+		})
+
+		It("should hold other identity keys of a registered party until it is repaired", func() {
+			first := registration("8801", party.URL)
+			Expect(s.AddParticipant(first)).To(Succeed())
+
+			renewed, err := s.RenewIdentityKey("8801")
+			Expect(err).To(BeNil())
+			Expect(renewed).To(BeFalse())
+
+			wiped := registration("8801", party.URL)
+			Expect(s.AddParticipant(wiped)).To(Succeed())
+
+			keys, err := s.GetIdentityKeys(rpc.Parties{"8801": party.URL})
+			Expect(err).To(BeNil())
+			Expect(keys["8801"]).To(Equal(first.IdentityKey))
+
+			signingKey, err := s.GetSigningKey("8801")
+			Expect(err).To(BeNil())
+			Expect(signingKey).To(Equal(first.SigningKey))
+
+			renewed, err = s.RenewIdentityKey("8801")
+			Expect(err).To(BeNil())
+			Expect(renewed).To(BeTrue())
+
+			keys, err = s.GetIdentityKeys(rpc.Parties{"8801": party.URL})
+			Expect(err).To(BeNil())
+			Expect(keys["8801"]).To(Equal(wiped.IdentityKey))
+
+			signingKey, err = s.GetSigningKey("8801")
+			Expect(err).To(BeNil())
+			Expect(signingKey).To(Equal(wiped.SigningKey))
+
+			renewed, err = s.RenewIdentityKey("8801")
+			Expect(err).To(BeNil())
+			Expect(renewed).To(BeFalse())
+		})
+	})
+})
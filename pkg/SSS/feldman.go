@@ -0,0 +1,80 @@
+package sss
+
+import (
+	"errors"
+	"fmt"
+	"frost/pkg/group"
+)
+
+var ErrInvalidShare = errors.New("share does not match commitments")
+
+// Commitments are the Feldman VSS commitments C_k = a_k·G to the coefficients
+// of a polynomial. Publishing them lets every holder check its own share
+// without learning anything about the secret beyond C_0 = secret·G.
+type Commitments []*group.Element
+
+// Commit returns the Feldman commitments to the polynomial's coefficients.
+func (p Polynomial) Commit() Commitments {
+	commitments := make(Commitments, len(p))
+	for i, coefficient := range p {
+		commitments[i] = group.ScalarBaseMult(coefficient)
+	}
+	return commitments
+}
+
+// PublicKey returns C_0, the commitment to the secret.
+func (c Commitments) PublicKey() *group.Element {
+	if len(c) == 0 {
+		return nil
+	}
+	return c[0]
+}
+
+// Evaluate returns Σ x^k·C_k, which equals f(x)·G for the committed polynomial.
+func (c Commitments) Evaluate(x *group.Scalar) *group.Element {
+	result := x.Group().Identity()
+
+	// Horner's method over the group: ((C_t·x + C_{t-1})·x + ...) + C_0
+	for i := len(c) - 1; i >= 0; i-- {
+		result = result.ScalarMult(x).Add(c[i])
+	}
+
+	return result
+}
+
+// Verify checks that share.Value·G matches the commitments evaluated at share.ID.
+func (c Commitments) Verify(share Share) error {
+	if len(c) == 0 {
+		return fmt.Errorf("no commitments to verify against")
+	}
+
+	if share.ID == nil || share.Value == nil {
+		return fmt.Errorf("share is missing identifier or value")
+	}
+
+	if err := validateIDs([]*group.Scalar{share.ID}); err != nil {
+		return err
+	}
+
+	g := share.Value.Group()
+	if share.ID.Group() != g {
+		return fmt.Errorf("share identifier and value are of different groups")
+	}
+
+	for _, commitment := range c {
+		if commitment == nil || commitment.Group() != g {
+			return fmt.Errorf("commitment is not an element of %s", g.Name())
+		}
+	}
+
+	if !group.ScalarBaseMult(share.Value).Equal(c.Evaluate(share.ID)) {
+		return ErrInvalidShare
+	}
+
+	return nil
+}
+
+// VerifyShare checks a received share against the dealer's published commitments.
+func VerifyShare(share Share, commitments Commitments) error {
+	return commitments.Verify(share)
+}
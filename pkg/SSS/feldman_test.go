@@ -0,0 +1,75 @@
+package sss_test
+
+import (
+	"encoding/json"
+	sss "frost/pkg/SSS"
+	"frost/pkg/group"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Feldman", func() {
+	var (
+		secret      *group.Scalar
+		polynomial  sss.Polynomial
+		commitments sss.Commitments
+		shares      []sss.Share
+	)
+
+	BeforeEach(func() {
+		var err error
+		secret, err = group.RandomScalar(group.Secp256k1)
+		Expect(err).To(BeNil())
+
+		polynomial, err = sss.MakePolynomial(secret, 2)
+		Expect(err).To(BeNil())
+
+		commitments = polynomial.Commit()
+		shares, err = polynomial.Shares(identifiers(5))
+		Expect(err).To(BeNil())
+	})
+
+	Context("While committing to a polynomial", func() {
+		It("should publish one commitment per coefficient", func() {
+			Expect(commitments).To(HaveLen(3))
+			Expect(commitments.PublicKey().Equal(group.ScalarBaseMult(secret))).To(BeTrue())
+		})
+
+		It("should survive a json round trip", func() {
+			data, err := json.Marshal(commitments)
+			Expect(err).To(BeNil())
+
+			var decoded sss.Commitments
+			Expect(json.Unmarshal(data, &decoded)).To(Succeed())
+			for i := range commitments {
+				Expect(decoded[i].Equal(commitments[i])).To(BeTrue())
+			}
+		})
+	})
+
+	Context("While verifying shares", func() {
+		It("should accept every honestly generated share", func() {
+			for _, share := range shares {
+				Expect(sss.VerifyShare(share, commitments)).To(Succeed())
+			}
+		})
+
+		It("should reject a tampered share", func() {
+			share := shares[0]
+			share.Value = share.Value.Add(scalar(1))
+			Expect(sss.VerifyShare(share, commitments)).To(MatchError(sss.ErrInvalidShare))
+		})
+
+		It("should reject a share presented under another identifier", func() {
+			share := sss.Share{ID: shares[1].ID, Value: shares[0].Value}
+			Expect(sss.VerifyShare(share, commitments)).To(MatchError(sss.ErrInvalidShare))
+		})
+
+		It("should reject a share against another polynomial's commitments", func() {
+			other, err := sss.MakePolynomial(secret, 2)
+			Expect(err).To(BeNil())
+			Expect(sss.VerifyShare(shares[0], other.Commit())).To(MatchError(sss.ErrInvalidShare))
+		})
+	})
+})
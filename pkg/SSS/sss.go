@@ -2,42 +2,161 @@
 package sss
 
 import (
-	"math/big"
-	"math/rand"
-
-	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+	"fmt"
+	"frost/pkg/group"
 )
 
-var Curve = secp256k1.S256()
+// Polynomial holds the coefficients a0..a(t-1) of f(x) = a0 + a1*x + ... over
+// the scalar field of a group, where a0 is the shared secret.
+type Polynomial []*group.Scalar
 
-type Polynomial []*big.Int
+// Share is the evaluation f(ID) of a polynomial at a non-zero identifier.
+type Share struct {
+	ID    *group.Scalar `json:"id"`
+	Value *group.Scalar `json:"value"`
+}
 
-func MakePolynomial(secret *big.Int, degree uint) Polynomial {
+// MakePolynomial returns a polynomial of the given degree with `secret` as its
+// constant term and the remaining coefficients drawn at random from the
+// secret's scalar field.
+func MakePolynomial(secret *group.Scalar, degree uint) (Polynomial, error) {
 	polynomial := make(Polynomial, degree+1)
 
 	polynomial[0] = secret
 
-	max := new(big.Int)
-	max.Exp(big.NewInt(2), big.NewInt(256), nil).Sub(max, big.NewInt(1))
-
 	for i := uint(1); i < degree+1; i++ {
-		polynomial[i] = new(big.Int).Rand(rand.New(rand.NewSource(9)), max)
+		coefficient, err := group.RandomScalar(secret.Group())
+		if err != nil {
+			return nil, err
+		}
+		polynomial[i] = coefficient
 	}
 
-	return polynomial
+	return polynomial, nil
 }
 
-func (p Polynomial) Evaluate(x *big.Int) *big.Int {
+// Group returns the group the polynomial's coefficients belong to.
+func (p Polynomial) Group() group.Group {
+	return p[0].Group()
+}
 
-	if x.Cmp(big.NewInt(0)) < 0 {
-		return p[0]
-	}
+// Threshold is the number of shares required to reconstruct the secret.
+func (p Polynomial) Threshold() uint {
+	return uint(len(p))
+}
 
-	result := new(big.Int).Set(p[0])
+// Evaluate computes f(x) using Horner's method.
+func (p Polynomial) Evaluate(x *group.Scalar) *group.Scalar {
+	result := group.ScalarFromInt(p.Group(), 0)
 
-	// for pow := uint(1); pow < uint(len(p)); pow++ {
-	// 	elem := new(big.Int).Exp(x, big.NewInt(int64(pow)), Curve.P)
-	// }
+	for i := len(p) - 1; i >= 0; i-- {
+		result = result.Mul(x).Add(p[i])
+	}
 
 	return result
 }
+
+// Shares evaluates the polynomial at every identifier. Identifiers must be
+// distinct and non-zero, since f(0) is the secret itself.
+func (p Polynomial) Shares(ids []*group.Scalar) ([]Share, error) {
+	if err := validateIDs(ids); err != nil {
+		return nil, err
+	}
+
+	shares := make([]Share, len(ids))
+	for i, id := range ids {
+		shares[i] = Share{
+			ID:    id,
+			Value: p.Evaluate(id),
+		}
+	}
+
+	return shares, nil
+}
+
+// LagrangeCoefficient returns λ_id(0) = Π_{j≠id} x_j / (x_j - x_id) over the
+// given set of identifiers, which must contain id.
+func LagrangeCoefficient(id *group.Scalar, ids []*group.Scalar) (*group.Scalar, error) {
+	return LagrangeCoefficientAt(id, ids, group.ScalarFromInt(id.Group(), 0))
+}
+
+// LagrangeCoefficientAt returns λ_id(x) = Π_{j≠id} (x - x_j) / (x_id - x_j)
+// over the given set of identifiers, which must contain id.
+func LagrangeCoefficientAt(id *group.Scalar, ids []*group.Scalar, x *group.Scalar) (*group.Scalar, error) {
+	if err := validateIDs(ids); err != nil {
+		return nil, err
+	}
+
+	numerator := group.ScalarFromInt(id.Group(), 1)
+	denominator := group.ScalarFromInt(id.Group(), 1)
+	found := false
+
+	for _, xj := range ids {
+		if xj.Group() != id.Group() {
+			return nil, fmt.Errorf("identifier of group %s in a %s signing set", xj.Group().Name(), id.Group().Name())
+		}
+
+		if xj.Equal(id) {
+			found = true
+			continue
+		}
+
+		numerator = numerator.Mul(x.Sub(xj))
+		denominator = denominator.Mul(id.Sub(xj))
+	}
+
+	if !found {
+		return nil, fmt.Errorf("identifier %s is not part of the signing set", id)
+	}
+
+	inverse, err := denominator.Invert()
+	if err != nil {
+		return nil, fmt.Errorf("identifiers are not invertible modulo group order")
+	}
+
+	return numerator.Mul(inverse), nil
+}
+
+// Interpolate reconstructs f(0) from the given shares. Any `threshold` shares
+// of a degree threshold-1 polynomial recover the same secret.
+func Interpolate(shares []Share) (*group.Scalar, error) {
+	if len(shares) == 0 {
+		return nil, fmt.Errorf("no shares to interpolate")
+	}
+
+	ids := make([]*group.Scalar, len(shares))
+	for i, share := range shares {
+		ids[i] = share.ID
+	}
+
+	secret := group.ScalarFromInt(shares[0].Value.Group(), 0)
+	for _, share := range shares {
+		lambda, err := LagrangeCoefficient(share.ID, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		secret = secret.Add(lambda.Mul(share.Value))
+	}
+
+	return secret, nil
+}
+
+func validateIDs(ids []*group.Scalar) error {
+	seen := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		if id == nil {
+			return fmt.Errorf("identifier is nil")
+		}
+
+		if id.IsZero() {
+			return fmt.Errorf("identifier must be non-zero modulo group order")
+		}
+
+		if _, ok := seen[id.String()]; ok {
+			return fmt.Errorf("duplicate identifier %s", id)
+		}
+		seen[id.String()] = struct{}{}
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package sss_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSSS(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SSS Suite")
+}
@@ -0,0 +1,123 @@
+package sss_test
+
+import (
+	sss "frost/pkg/SSS"
+	"frost/pkg/group"
+	"math/big"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// subsets returns every k-sized subset of shares.
+func subsets(shares []sss.Share, k int) [][]sss.Share {
+	if k == 0 {
+		return [][]sss.Share{{}}
+	}
+	if len(shares) < k {
+		return nil
+	}
+
+	var result [][]sss.Share
+	for _, rest := range subsets(shares[1:], k-1) {
+		result = append(result, append([]sss.Share{shares[0]}, rest...))
+	}
+	return append(result, subsets(shares[1:], k)...)
+}
+
+func identifiers(n int) []*group.Scalar {
+	ids := make([]*group.Scalar, n)
+	for i := range ids {
+		ids[i] = scalar(int64(i + 1))
+	}
+	return ids
+}
+
+func scalar(v int64) *group.Scalar {
+	return group.ScalarFromInt(group.Secp256k1, v)
+}
+
+var _ = Describe("SSS", func() {
+	var (
+		secret     *group.Scalar
+		polynomial sss.Polynomial
+	)
+
+	BeforeEach(func() {
+		var err error
+		secret, err = group.RandomScalar(group.Secp256k1)
+		Expect(err).To(BeNil())
+
+		polynomial, err = sss.MakePolynomial(secret, 2)
+		Expect(err).To(BeNil())
+	})
+
+	Context("While generating polynomials", func() {
+		It("should keep the secret as the constant term", func() {
+			Expect(polynomial.Threshold()).To(Equal(uint(3)))
+			Expect(polynomial.Evaluate(scalar(0)).Equal(secret)).To(BeTrue())
+		})
+
+		It("should draw non-zero coefficients below the group order", func() {
+			for _, coefficient := range polynomial {
+				Expect(coefficient.IsZero()).To(BeFalse())
+				Expect(coefficient.BigInt().Cmp(group.Secp256k1.Order())).To(BeNumerically("<", 0))
+			}
+		})
+
+		It("should evaluate modulo the group order", func() {
+			p := sss.Polynomial{scalar(3), scalar(2), scalar(1)}
+			Expect(p.Evaluate(scalar(2)).Equal(scalar(11))).To(BeTrue())
+
+			n := new(big.Int).Add(group.Secp256k1.Order(), big.NewInt(2))
+			Expect(p.Evaluate(group.NewScalar(group.Secp256k1, n)).Equal(scalar(11))).To(BeTrue())
+		})
+	})
+
+	Context("While reconstructing the secret", func() {
+		It("should recover the secret from any t-of-n subset", func() {
+			shares, err := polynomial.Shares(identifiers(5))
+			Expect(err).To(BeNil())
+
+			all := subsets(shares, 3)
+			Expect(all).To(HaveLen(10))
+			for _, subset := range all {
+				recovered, err := sss.Interpolate(subset)
+				Expect(err).To(BeNil())
+				Expect(recovered.Equal(secret)).To(BeTrue())
+			}
+		})
+
+		It("should recover the secret from more than t shares", func() {
+			shares, err := polynomial.Shares(identifiers(5))
+			Expect(err).To(BeNil())
+
+			recovered, err := sss.Interpolate(shares)
+			Expect(err).To(BeNil())
+			Expect(recovered.Equal(secret)).To(BeTrue())
+		})
+
+		It("should not recover the secret from fewer than t shares", func() {
+			shares, err := polynomial.Shares(identifiers(5))
+			Expect(err).To(BeNil())
+
+			recovered, err := sss.Interpolate(shares[:2])
+			Expect(err).To(BeNil())
+			Expect(recovered.Equal(secret)).To(BeFalse())
+		})
+
+		It("should reject zero and duplicate identifiers", func() {
+			_, err := polynomial.Shares([]*group.Scalar{scalar(0), scalar(1)})
+			Expect(err).ToNot(BeNil())
+
+			n := new(big.Int).Add(group.Secp256k1.Order(), big.NewInt(1))
+			_, err = polynomial.Shares([]*group.Scalar{scalar(1), group.NewScalar(group.Secp256k1, n)})
+			Expect(err).ToNot(BeNil())
+		})
+
+		It("should reject a coefficient for an identifier outside the set", func() {
+			_, err := sss.LagrangeCoefficient(scalar(4), identifiers(3))
+			Expect(err).ToNot(BeNil())
+		})
+	})
+})
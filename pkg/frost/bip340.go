@@ -0,0 +1,173 @@
+package frost
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"frost/pkg/group"
+	"math/big"
+)
+
+// BIP340MessageLength is the size of the messages BIP-340 signing accepts, a
+// 32 byte digest such as a Taproot sighash.
+const BIP340MessageLength = 32
+
+// BIP340 switches a signing package to BIP-340 Schnorr signatures: x-only keys,
+// an even-Y R and the tagged challenge hash.
+type BIP340 struct {
+	// Taproot signs for the BIP-341 output key Q = P + t·G instead of the
+	// group key itself, as needed for a key-path spend.
+	Taproot bool `json:"taproot"`
+
+	// MerkleRoot is the script tree root the output key commits to. It is
+	// empty for outputs without a script tree (BIP-86).
+	MerkleRoot []byte `json:"merkle_root,omitempty"`
+}
+
+// OutputKey is the even-Y key a BIP-340 signature verifies under together
+// with the adjustments signers and the aggregator apply to reach it: its
+// secret is KeyFactor·s + Tweak, where s is the group secret and KeyFactor is
+// ±1.
+type OutputKey struct {
+	InternalKey *group.Element
+	Key         *group.Element
+	KeyFactor   *group.Scalar
+	Tweak       *group.Scalar
+}
+
+// Validate checks that the merkle root is only set for Taproot outputs and is
+// a single hash.
+func (b BIP340) Validate() error {
+	if len(b.MerkleRoot) == 0 {
+		return nil
+	}
+
+	if !b.Taproot {
+		return fmt.Errorf("merkle root requires a taproot output")
+	}
+
+	if len(b.MerkleRoot) != sha256.Size {
+		return fmt.Errorf("invalid merkle root length %d", len(b.MerkleRoot))
+	}
+
+	return nil
+}
+
+// OutputKey normalizes the group key to even Y and, for Taproot, applies the
+// BIP-341 tweak.
+func (b BIP340) OutputKey(groupPublicKey *group.Element) (OutputKey, error) {
+	if err := b.Validate(); err != nil {
+		return OutputKey{}, err
+	}
+
+	if groupPublicKey == nil || groupPublicKey.Group() != group.Secp256k1 {
+		return OutputKey{}, fmt.Errorf("bip340 keys must be secp256k1 elements")
+	}
+
+	if groupPublicKey.IsIdentity() {
+		return OutputKey{}, ErrIdentityElement
+	}
+
+	internal, internalFactor := evenY(groupPublicKey)
+	if !b.Taproot {
+		return OutputKey{InternalKey: internal, Key: internal, KeyFactor: internalFactor, Tweak: group.ScalarFromInt(group.Secp256k1, 0)}, nil
+	}
+
+	t, err := group.DeserializeScalar(group.Secp256k1, TaggedHash("TapTweak", XOnly(internal), b.MerkleRoot))
+	if err != nil {
+		return OutputKey{}, fmt.Errorf("taproot tweak is not a valid scalar")
+	}
+
+	Q := internal.Add(group.ScalarBaseMult(t))
+	if Q.IsIdentity() {
+		return OutputKey{}, ErrIdentityElement
+	}
+
+	key, outputFactor := evenY(Q)
+	return OutputKey{
+		InternalKey: internal,
+		Key:         key,
+		KeyFactor:   internalFactor.Mul(outputFactor),
+		Tweak:       t.Mul(outputFactor),
+	}, nil
+}
+
+// TaggedHash is SHA256(SHA256(tag) || SHA256(tag) || m).
+func TaggedHash(tag string, parts ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, part := range parts {
+		h.Write(part)
+	}
+	return h.Sum(nil)
+}
+
+// BIP340Challenge computes e = H_BIP0340/challenge(R_x || P_x || m) mod N.
+func BIP340Challenge(R, key *group.Element, message []byte) *group.Scalar {
+	e := new(big.Int).SetBytes(TaggedHash("BIP0340/challenge", XOnly(R), XOnly(key), message))
+	return group.NewScalar(group.Secp256k1, e)
+}
+
+// XOnly returns the 32 byte x coordinate of a secp256k1 element.
+func XOnly(p *group.Element) []byte {
+	return p.Bytes()[1:]
+}
+
+// LiftX returns the even-Y point with the given x-only encoding.
+func LiftX(x []byte) (*group.Element, error) {
+	if len(x) != 32 {
+		return nil, fmt.Errorf("invalid x-only key length %d", len(x))
+	}
+	return group.DeserializeElement(group.Secp256k1, append([]byte{0x02}, x...))
+}
+
+// EncodeBIP340 serializes the signature as R_x || z.
+func (s Signature) EncodeBIP340() ([]byte, error) {
+	if s.R == nil || s.R.IsIdentity() || s.Z == nil || s.R.Group() != group.Secp256k1 {
+		return nil, ErrInvalidSignature
+	}
+
+	if !hasEvenY(s.R) {
+		return nil, fmt.Errorf("signature nonce does not have an even Y coordinate")
+	}
+
+	return append(XOnly(s.R), s.Z.Bytes()...), nil
+}
+
+// VerifyBIP340 checks a signature against an x-only key per BIP-340: R' =
+// z·G - e·P must be even and carry the x coordinate of R.
+func VerifyBIP340(key *group.Element, message []byte, signature Signature) error {
+	if key == nil || key.IsIdentity() || signature.R == nil || signature.R.IsIdentity() || signature.Z == nil {
+		return ErrInvalidSignature
+	}
+
+	if key.Group() != group.Secp256k1 || signature.R.Group() != group.Secp256k1 || signature.Z.Group() != group.Secp256k1 {
+		return ErrInvalidSignature
+	}
+
+	P, _ := evenY(key)
+	e := BIP340Challenge(signature.R, P, message)
+
+	R := group.ScalarBaseMult(signature.Z).Sub(P.ScalarMult(e))
+	if R.IsIdentity() || !hasEvenY(R) || string(XOnly(R)) != string(XOnly(signature.R)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// evenY returns p or -p, whichever has an even Y coordinate, and the factor
+// (1 or -1) it was multiplied by.
+func evenY(p *group.Element) (*group.Element, *group.Scalar) {
+	if hasEvenY(p) {
+		return p, group.ScalarFromInt(group.Secp256k1, 1)
+	}
+	return p.Negate(), group.ScalarFromInt(group.Secp256k1, -1)
+}
+
+// hasEvenY reads the parity from the SEC1 compressed prefix.
+func hasEvenY(p *group.Element) bool {
+	return p.Bytes()[0] == 0x02
+}
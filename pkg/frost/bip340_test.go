@@ -0,0 +1,128 @@
+package frost_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	sss "frost/pkg/SSS"
+	"frost/pkg/frost"
+	"frost/pkg/group"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BIP-340 signing", func() {
+	cs := frost.Secp256k1SHA256
+	g := cs.Group()
+	message := sha256.Sum256([]byte("spend"))
+
+	// sign runs a 2-of-3 signing session over a fresh key and returns the
+	// group key and the aggregated signature.
+	sign := func(bip340 frost.BIP340) (*group.Element, frost.Signature) {
+		secret, err := group.RandomScalar(g)
+		Expect(err).To(BeNil())
+
+		polynomial, err := sss.MakePolynomial(secret, 1)
+		Expect(err).To(BeNil())
+		groupPublicKey := polynomial.Commit().PublicKey()
+
+		shares, err := polynomial.Shares([]*group.Scalar{group.ScalarFromInt(g, 1), group.ScalarFromInt(g, 2), group.ScalarFromInt(g, 3)})
+		Expect(err).To(BeNil())
+
+		keys := []frost.KeyShare{}
+		nonces := []*frost.Nonce{}
+		var commitments []frost.SigningCommitment
+		for _, share := range []sss.Share{shares[0], shares[2]} {
+			key := frost.KeyShare{Identifier: share.ID, SigningShare: share.Value, GroupPublicKey: groupPublicKey}
+			nonce, err := frost.NewNonce(cs, 0, key.SigningShare)
+			Expect(err).To(BeNil())
+
+			keys = append(keys, key)
+			nonces = append(nonces, nonce)
+			commitments = append(commitments, frost.SigningCommitment{
+				Party:      fmt.Sprintf("party-%s", key.Identifier),
+				Identifier: key.Identifier,
+				Commitment: nonce.Commit(),
+			})
+		}
+
+		pkg, err := frost.NewBIP340SigningPackage(cs, message[:], commitments, bip340)
+		Expect(err).To(BeNil())
+
+		sigShares := make(map[string]*group.Scalar)
+		for i, key := range keys {
+			z, err := frost.Sign(cs, key, nonces[i], pkg)
+			Expect(err).To(BeNil())
+			Expect(frost.VerifySignatureShare(cs, key.Identifier, group.ScalarBaseMult(key.SigningShare), z, groupPublicKey, pkg)).To(Succeed())
+			sigShares[key.Identifier.String()] = z
+		}
+
+		signature, err := frost.Aggregate(cs, groupPublicKey, pkg, sigShares)
+		Expect(err).To(BeNil())
+		return groupPublicKey, signature
+	}
+
+	verify := func(key *group.Element, signature frost.Signature) bool {
+		pubKey, err := schnorr.ParsePubKey(frost.XOnly(key))
+		Expect(err).To(BeNil())
+
+		encoded, err := signature.EncodeBIP340()
+		Expect(err).To(BeNil())
+
+		sig, err := schnorr.ParseSignature(encoded)
+		Expect(err).To(BeNil())
+
+		return sig.Verify(message[:], pubKey)
+	}
+
+	It("should produce signatures btcec verifies under the x-only group key", func() {
+		// enough runs to hit group keys and nonces of both parities
+		for i := 0; i < 8; i++ {
+			groupPublicKey, signature := sign(frost.BIP340{})
+			Expect(verify(groupPublicKey, signature)).To(BeTrue())
+			Expect(frost.VerifyBIP340(groupPublicKey, message[:], signature)).To(Succeed())
+		}
+	})
+
+	It("should sign for a taproot output key with and without a script tree", func() {
+		merkleRoot := sha256.Sum256([]byte("script tree"))
+
+		for _, bip340 := range []frost.BIP340{{Taproot: true}, {Taproot: true, MerkleRoot: merkleRoot[:]}} {
+			for i := 0; i < 4; i++ {
+				groupPublicKey, signature := sign(bip340)
+
+				output, err := bip340.OutputKey(groupPublicKey)
+				Expect(err).To(BeNil())
+				Expect(verify(output.Key, signature)).To(BeTrue())
+				Expect(verify(groupPublicKey, signature)).To(BeFalse())
+			}
+		}
+	})
+
+	It("should tweak keys as in BIP-86", func() {
+		internal, err := hex.DecodeString("cc8a4bc64d897bddc5fbc2f670f7a8ba0b386779106cf1223c6fc5d7cd6fc115")
+		Expect(err).To(BeNil())
+
+		P, err := frost.LiftX(internal)
+		Expect(err).To(BeNil())
+
+		output, err := frost.BIP340{Taproot: true}.OutputKey(P)
+		Expect(err).To(BeNil())
+		Expect(hex.EncodeToString(frost.XOnly(output.Key))).To(Equal("a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c"))
+	})
+
+	It("should reject messages that are not 32 bytes", func() {
+		one := group.ScalarFromInt(g, 1)
+		nonce, err := frost.NewNonce(cs, 0, one)
+		Expect(err).To(BeNil())
+
+		commitments := []frost.SigningCommitment{{Party: "party-1", Identifier: one, Commitment: nonce.Commit()}}
+		_, err = frost.NewBIP340SigningPackage(cs, []byte("short"), commitments, frost.BIP340{})
+		Expect(err).ToNot(BeNil())
+
+		_, err = frost.NewBIP340SigningPackage(cs, message[:], commitments, frost.BIP340{MerkleRoot: message[:]})
+		Expect(err).ToNot(BeNil())
+	})
+})
@@ -0,0 +1,192 @@
+package frost
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"frost/pkg/group"
+	"math/big"
+)
+
+var ErrIdentityElement = errors.New("cannot serialize the identity element")
+
+// Ciphersuite is a FROST ciphersuite as in RFC 9591 section 6: a prime-order
+// group together with the hash functions H1 to H5. HDKG derives the
+// challenge of the DKG proofs of knowledge.
+type Ciphersuite interface {
+	// ID is the ciphersuite's context string.
+	ID() string
+	Group() group.Group
+
+	// H1 derives binding factors.
+	H1(m []byte) *group.Scalar
+	// H2 derives the signature challenge.
+	H2(m []byte) *group.Scalar
+	// H3 derives nonces.
+	H3(m []byte) *group.Scalar
+	// H4 hashes the message before it is bound into the binding factors.
+	H4(m []byte) []byte
+	// H5 hashes the encoded commitment list before it is bound into the
+	// binding factors.
+	H5(m []byte) []byte
+	// HDKG derives the challenge of a DKG proof of knowledge.
+	HDKG(m []byte) *group.Scalar
+}
+
+var ciphersuites = make(map[string]Ciphersuite)
+
+func register(cs Ciphersuite) Ciphersuite {
+	ciphersuites[cs.ID()] = cs
+	return cs
+}
+
+// CiphersuiteByID returns a registered ciphersuite by its context string.
+func CiphersuiteByID(id string) (Ciphersuite, error) {
+	cs, ok := ciphersuites[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown ciphersuite %s", id)
+	}
+	return cs, nil
+}
+
+// Secp256k1SHA256 is FROST(secp256k1, SHA-256).
+var Secp256k1SHA256 = register(secp256k1SHA256{})
+
+type secp256k1SHA256 struct{}
+
+func (secp256k1SHA256) ID() string         { return "FROST-secp256k1-SHA256-v1" }
+func (secp256k1SHA256) Group() group.Group { return group.Secp256k1 }
+
+func (cs secp256k1SHA256) H1(m []byte) *group.Scalar   { return cs.hashToScalar("rho", m) }
+func (cs secp256k1SHA256) H2(m []byte) *group.Scalar   { return cs.hashToScalar("chal", m) }
+func (cs secp256k1SHA256) H3(m []byte) *group.Scalar   { return cs.hashToScalar("nonce", m) }
+func (cs secp256k1SHA256) H4(m []byte) []byte          { return cs.hash("msg", m) }
+func (cs secp256k1SHA256) H5(m []byte) []byte          { return cs.hash("com", m) }
+func (cs secp256k1SHA256) HDKG(m []byte) *group.Scalar { return cs.hashToScalar("dkg", m) }
+
+func (cs secp256k1SHA256) hash(tag string, m []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte(cs.ID() + tag))
+	h.Write(m)
+	return h.Sum(nil)
+}
+
+// hashToScalar is hash_to_field from RFC 9380 with expand_message_xmd(SHA-256)
+// and L = 48, producing a single scalar.
+func (cs secp256k1SHA256) hashToScalar(tag string, m []byte) *group.Scalar {
+	uniform := expandMessageXMD(m, []byte(cs.ID()+tag), 48)
+	return group.NewScalar(group.Secp256k1, new(big.Int).SetBytes(uniform))
+}
+
+// NonceGenerate is nonce_generate from RFC 9591: fresh randomness hedged with
+// the signer's secret, so a weak RNG alone does not leak the signing share.
+func NonceGenerate(cs Ciphersuite, secret *group.Scalar) (*group.Scalar, error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return nil, err
+	}
+	return DeriveNonce(cs, random, secret), nil
+}
+
+// DeriveNonce computes H3(random || SerializeScalar(secret)).
+func DeriveNonce(cs Ciphersuite, random []byte, secret *group.Scalar) *group.Scalar {
+	return cs.H3(append(append([]byte{}, random...), secret.Bytes()...))
+}
+
+// SerializeElement encodes a group element, rejecting the identity.
+func SerializeElement(p *group.Element) ([]byte, error) {
+	if p == nil || p.IsIdentity() {
+		return nil, ErrIdentityElement
+	}
+	return p.Bytes(), nil
+}
+
+// DeserializeElement decodes a group element, rejecting the identity.
+func DeserializeElement(cs Ciphersuite, b []byte) (*group.Element, error) {
+	if len(b) != cs.Group().ElementLength() {
+		return nil, fmt.Errorf("invalid element length %d", len(b))
+	}
+
+	p, err := group.DeserializeElement(cs.Group(), b)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.IsIdentity() {
+		return nil, ErrIdentityElement
+	}
+
+	return p, nil
+}
+
+// Encode serializes the signature as SerializeElement(R) || SerializeScalar(z).
+func (s Signature) Encode() ([]byte, error) {
+	R, err := SerializeElement(s.R)
+	if err != nil {
+		return nil, err
+	}
+	return append(R, s.Z.Bytes()...), nil
+}
+
+// DecodeSignature parses SerializeElement(R) || SerializeScalar(z).
+func DecodeSignature(cs Ciphersuite, b []byte) (Signature, error) {
+	elementLength := cs.Group().ElementLength()
+	if len(b) != elementLength+cs.Group().ScalarLength() {
+		return Signature{}, fmt.Errorf("invalid signature length %d", len(b))
+	}
+
+	R, err := DeserializeElement(cs, b[:elementLength])
+	if err != nil {
+		return Signature{}, err
+	}
+
+	z, err := group.DeserializeScalar(cs.Group(), b[elementLength:])
+	if err != nil {
+		return Signature{}, err
+	}
+
+	return Signature{R: R, Z: z}, nil
+}
+
+// expandMessageXMD is expand_message_xmd from RFC 9380 section 5.3.1 for SHA-256.
+func expandMessageXMD(msg, dst []byte, length int) []byte {
+	const (
+		blockSize = 64
+		outSize   = sha256.Size
+	)
+
+	ell := (length + outSize - 1) / outSize
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+
+	h := sha256.New()
+	h.Write(make([]byte, blockSize))
+	h.Write(msg)
+	h.Write([]byte{byte(length >> 8), byte(length)})
+	h.Write([]byte{0})
+	h.Write(dstPrime)
+	b0 := h.Sum(nil)
+
+	h.Reset()
+	h.Write(b0)
+	h.Write([]byte{1})
+	h.Write(dstPrime)
+	bi := h.Sum(nil)
+
+	uniform := append([]byte{}, bi...)
+	for i := 2; i <= ell; i++ {
+		xored := make([]byte, outSize)
+		for j := range xored {
+			xored[j] = b0[j] ^ bi[j]
+		}
+
+		h.Reset()
+		h.Write(xored)
+		h.Write([]byte{byte(i)})
+		h.Write(dstPrime)
+		bi = h.Sum(nil)
+		uniform = append(uniform, bi...)
+	}
+
+	return uniform[:length]
+}
@@ -0,0 +1,46 @@
+package frost
+
+import (
+	"crypto/sha512"
+	"frost/pkg/group"
+	"math/big"
+)
+
+// Ed25519SHA512 is FROST(Ed25519, SHA-512). Its aggregated signatures are
+// plain RFC 8032 Ed25519 signatures under the group key.
+var Ed25519SHA512 = register(ed25519SHA512{})
+
+type ed25519SHA512 struct{}
+
+func (ed25519SHA512) ID() string         { return "FROST-ED25519-SHA512-v1" }
+func (ed25519SHA512) Group() group.Group { return group.Edwards25519 }
+
+func (cs ed25519SHA512) H1(m []byte) *group.Scalar   { return cs.hashToScalar(cs.ID()+"rho", m) }
+func (cs ed25519SHA512) H3(m []byte) *group.Scalar   { return cs.hashToScalar(cs.ID()+"nonce", m) }
+func (cs ed25519SHA512) H4(m []byte) []byte          { return cs.hash(cs.ID()+"msg", m) }
+func (cs ed25519SHA512) H5(m []byte) []byte          { return cs.hash(cs.ID()+"com", m) }
+func (cs ed25519SHA512) HDKG(m []byte) *group.Scalar { return cs.hashToScalar(cs.ID()+"dkg", m) }
+
+// H2 carries no context string so the challenge matches RFC 8032 verification.
+func (cs ed25519SHA512) H2(m []byte) *group.Scalar { return cs.hashToScalar("", m) }
+
+func (ed25519SHA512) hash(prefix string, m []byte) []byte {
+	h := sha512.New()
+	h.Write([]byte(prefix))
+	h.Write(m)
+	return h.Sum(nil)
+}
+
+// hashToScalar interprets the SHA-512 digest as a little-endian integer
+// reduced modulo the group order.
+func (cs ed25519SHA512) hashToScalar(prefix string, m []byte) *group.Scalar {
+	return group.NewScalar(group.Edwards25519, littleEndian(cs.hash(prefix, m)))
+}
+
+func littleEndian(b []byte) *big.Int {
+	reversed := make([]byte, len(b))
+	for i := range b {
+		reversed[len(b)-1-i] = b[i]
+	}
+	return new(big.Int).SetBytes(reversed)
+}
@@ -0,0 +1,94 @@
+package frost_test
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	sss "frost/pkg/SSS"
+	"frost/pkg/frost"
+	"frost/pkg/group"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FROST(Ed25519, SHA-512)", func() {
+	cs := frost.Ed25519SHA512
+	g := cs.Group()
+
+	It("should produce signatures crypto/ed25519 verifies under the group key", func() {
+		for i := 0; i < 4; i++ {
+			secret, err := group.RandomScalar(g)
+			Expect(err).To(BeNil())
+
+			polynomial, err := sss.MakePolynomial(secret, 1)
+			Expect(err).To(BeNil())
+			groupPublicKey := polynomial.Commit().PublicKey()
+
+			shares, err := polynomial.Shares([]*group.Scalar{group.ScalarFromInt(g, 1), group.ScalarFromInt(g, 2), group.ScalarFromInt(g, 3)})
+			Expect(err).To(BeNil())
+
+			message := []byte(fmt.Sprintf("solana transfer %d", i))
+
+			keys := []frost.KeyShare{}
+			nonces := []*frost.Nonce{}
+			var commitments []frost.SigningCommitment
+			for _, share := range []sss.Share{shares[1], shares[2]} {
+				key := frost.KeyShare{Identifier: share.ID, SigningShare: share.Value, GroupPublicKey: groupPublicKey}
+				nonce, err := frost.NewNonce(cs, 0, key.SigningShare)
+				Expect(err).To(BeNil())
+
+				keys = append(keys, key)
+				nonces = append(nonces, nonce)
+				commitments = append(commitments, frost.SigningCommitment{
+					Party:      fmt.Sprintf("party-%s", key.Identifier),
+					Identifier: key.Identifier,
+					Commitment: nonce.Commit(),
+				})
+			}
+
+			pkg, err := frost.NewSigningPackage(cs, message, commitments)
+			Expect(err).To(BeNil())
+
+			sigShares := make(map[string]*group.Scalar)
+			for i, key := range keys {
+				z, err := frost.Sign(cs, key, nonces[i], pkg)
+				Expect(err).To(BeNil())
+				sigShares[key.Identifier.String()] = z
+			}
+
+			signature, err := frost.Aggregate(cs, groupPublicKey, pkg, sigShares)
+			Expect(err).To(BeNil())
+
+			encoded, err := signature.Encode()
+			Expect(err).To(BeNil())
+			Expect(encoded).To(HaveLen(ed25519.SignatureSize))
+			Expect(ed25519.Verify(ed25519.PublicKey(groupPublicKey.Bytes()), message, encoded)).To(BeTrue())
+			Expect(ed25519.Verify(ed25519.PublicKey(groupPublicKey.Bytes()), []byte("other"), encoded)).To(BeFalse())
+		}
+	})
+
+	It("should produce the RFC 9591 signature crypto/ed25519 accepts", func() {
+		decode := func(s string) []byte {
+			b, err := hex.DecodeString(s)
+			Expect(err).To(BeNil())
+			return b
+		}
+
+		groupPublicKey := decode("15d21ccd7ee42959562fc8aa63224c8851fb3ec85a3faf66040d380fb9738673")
+		signature := decode("36282629c383bb820a88b71cae937d41f2f2adfcc3d02e55507e2fb9e2dd3cbebd9d2b0844e49ae0f3fa935161e1419aab7b47d21a37ebeae1f17d4987b3160b")
+		Expect(ed25519.Verify(ed25519.PublicKey(groupPublicKey), []byte("test"), signature)).To(BeTrue())
+	})
+
+	It("should reject points outside the prime-order subgroup", func() {
+		// the point of order 2, (0, -1)
+		b := make([]byte, 32)
+		b[0] = 0xec
+		for i := 1; i < 31; i++ {
+			b[i] = 0xff
+		}
+		b[31] = 0x7f
+		_, err := group.DeserializeElement(g, b)
+		Expect(err).ToNot(BeNil())
+	})
+})
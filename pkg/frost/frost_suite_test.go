@@ -0,0 +1,13 @@
+package frost_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestFrost(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Frost Suite")
+}
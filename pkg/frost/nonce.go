@@ -0,0 +1,64 @@
+// FROST signing primitives shared by parties and the signature aggregator
+package frost
+
+import (
+	"frost/pkg/group"
+)
+
+// Nonce is a party's secret single-use nonce pair (d, e) for one signing session.
+type Nonce struct {
+	Index   uint          `json:"index"`
+	Hiding  *group.Scalar `json:"hiding"`
+	Binding *group.Scalar `json:"binding"`
+}
+
+// NonceCommitment is the public commitment (D, E) = (d·G, e·G) to a Nonce,
+// published ahead of time during preprocessing.
+type NonceCommitment struct {
+	Index   uint           `json:"index"`
+	Hiding  *group.Element `json:"hiding"`
+	Binding *group.Element `json:"binding"`
+}
+
+// NewNonce samples a fresh hiding and binding nonce with nonce_generate,
+// hedged with the signer's signing share.
+func NewNonce(cs Ciphersuite, index uint, secret *group.Scalar) (*Nonce, error) {
+	hiding, err := NonceGenerate(cs, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	binding, err := NonceGenerate(cs, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Nonce{Index: index, Hiding: hiding, Binding: binding}, nil
+}
+
+// Commit returns the public commitment to the nonce pair.
+func (n *Nonce) Commit() NonceCommitment {
+	return NonceCommitment{
+		Index:   n.Index,
+		Hiding:  group.ScalarBaseMult(n.Hiding),
+		Binding: group.ScalarBaseMult(n.Binding),
+	}
+}
+
+// Preprocess samples `count` nonces with consecutive indices starting at `from`.
+func Preprocess(cs Ciphersuite, from, count uint, secret *group.Scalar) ([]*Nonce, []NonceCommitment, error) {
+	nonces := make([]*Nonce, count)
+	commitments := make([]NonceCommitment, count)
+
+	for i := uint(0); i < count; i++ {
+		nonce, err := NewNonce(cs, from+i, secret)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		nonces[i] = nonce
+		commitments[i] = nonce.Commit()
+	}
+
+	return nonces, commitments, nil
+}
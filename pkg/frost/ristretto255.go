@@ -0,0 +1,34 @@
+package frost
+
+import (
+	"crypto/sha512"
+	"frost/pkg/group"
+)
+
+// Ristretto255SHA512 is FROST(ristretto255, SHA-512).
+var Ristretto255SHA512 = register(ristretto255SHA512{})
+
+type ristretto255SHA512 struct{}
+
+func (ristretto255SHA512) ID() string         { return "FROST-RISTRETTO255-SHA512-v1" }
+func (ristretto255SHA512) Group() group.Group { return group.Ristretto255 }
+
+func (cs ristretto255SHA512) H1(m []byte) *group.Scalar   { return cs.hashToScalar("rho", m) }
+func (cs ristretto255SHA512) H2(m []byte) *group.Scalar   { return cs.hashToScalar("chal", m) }
+func (cs ristretto255SHA512) H3(m []byte) *group.Scalar   { return cs.hashToScalar("nonce", m) }
+func (cs ristretto255SHA512) H4(m []byte) []byte          { return cs.hash("msg", m) }
+func (cs ristretto255SHA512) H5(m []byte) []byte          { return cs.hash("com", m) }
+func (cs ristretto255SHA512) HDKG(m []byte) *group.Scalar { return cs.hashToScalar("dkg", m) }
+
+func (cs ristretto255SHA512) hash(tag string, m []byte) []byte {
+	h := sha512.New()
+	h.Write([]byte(cs.ID() + tag))
+	h.Write(m)
+	return h.Sum(nil)
+}
+
+// hashToScalar reduces the 64 byte digest as a little-endian integer, which
+// is the ristretto255 scalar FromUniformBytes mapping.
+func (cs ristretto255SHA512) hashToScalar(tag string, m []byte) *group.Scalar {
+	return group.NewScalar(group.Ristretto255, littleEndian(cs.hash(tag, m)))
+}
@@ -0,0 +1,350 @@
+package frost
+
+import (
+	"errors"
+	"fmt"
+	sss "frost/pkg/SSS"
+	"frost/pkg/group"
+	"sort"
+)
+
+var (
+	ErrInvalidSignatureShare = errors.New("invalid signature share")
+	ErrInvalidSignature      = errors.New("invalid signature")
+)
+
+// KeyShare is the key material a party signs with.
+type KeyShare struct {
+	Identifier     *group.Scalar
+	SigningShare   *group.Scalar
+	GroupPublicKey *group.Element
+}
+
+// SigningCommitment is a signer's nonce commitment as listed in a signing package.
+type SigningCommitment struct {
+	Party      string          `json:"party"`
+	Identifier *group.Scalar   `json:"identifier"`
+	Commitment NonceCommitment `json:"commitment"`
+}
+
+// SigningPackage is what the signature aggregator hands to every chosen signer.
+type SigningPackage struct {
+	Message     []byte              `json:"message"`
+	Commitments []SigningCommitment `json:"commitments"`
+
+	// BIP340 selects BIP-340 signing; RFC 9591 signing is used when nil.
+	BIP340 *BIP340 `json:"bip340,omitempty"`
+}
+
+// Signature is an aggregated Schnorr signature (R, z) with z·G = R + c·Y.
+type Signature struct {
+	R *group.Element `json:"r"`
+	Z *group.Scalar  `json:"z"`
+}
+
+// session is what every signer and the aggregator derive identically from a
+// signing package: the key the signature verifies under, the binding factors,
+// the group commitment R and the challenge c. keyFactor and nonceFactor are
+// the ±1 sign flips applied to signing shares and nonces, and tweak is added
+// to z times c by the aggregator.
+type session struct {
+	key            *group.Element
+	bindingFactors map[string]*group.Scalar
+	R              *group.Element
+	c              *group.Scalar
+
+	keyFactor   *group.Scalar
+	nonceFactor *group.Scalar
+	tweak       *group.Scalar
+}
+
+// NewSigningPackage sorts the commitments by identifier and checks that every
+// signer appears once.
+func NewSigningPackage(cs Ciphersuite, message []byte, commitments []SigningCommitment) (SigningPackage, error) {
+	return newSigningPackage(cs, message, commitments, nil)
+}
+
+// NewBIP340SigningPackage is NewSigningPackage for a BIP-340 signature.
+func NewBIP340SigningPackage(cs Ciphersuite, message []byte, commitments []SigningCommitment, bip340 BIP340) (SigningPackage, error) {
+	return newSigningPackage(cs, message, commitments, &bip340)
+}
+
+func newSigningPackage(cs Ciphersuite, message []byte, commitments []SigningCommitment, bip340 *BIP340) (SigningPackage, error) {
+	for _, commitment := range commitments {
+		if commitment.Identifier == nil {
+			return SigningPackage{}, fmt.Errorf("invalid identifier for %s", commitment.Party)
+		}
+	}
+
+	sorted := make([]SigningCommitment, len(commitments))
+	copy(sorted, commitments)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Identifier.BigInt().Cmp(sorted[j].Identifier.BigInt()) < 0
+	})
+
+	pkg := SigningPackage{Message: message, Commitments: sorted, BIP340: bip340}
+	if err := pkg.Validate(cs); err != nil {
+		return SigningPackage{}, err
+	}
+
+	return pkg, nil
+}
+
+// Validate checks that the commitment list is sorted by distinct identifiers
+// and carries valid nonce commitments of the ciphersuite's group.
+func (pkg SigningPackage) Validate(cs Ciphersuite) error {
+	if len(pkg.Commitments) == 0 {
+		return fmt.Errorf("signing package has no commitments")
+	}
+
+	g := cs.Group()
+	if pkg.BIP340 != nil {
+		if g != group.Secp256k1 {
+			return fmt.Errorf("bip340 signing requires secp256k1, not %s", g.Name())
+		}
+
+		if len(pkg.Message) != BIP340MessageLength {
+			return fmt.Errorf("bip340 messages must be %d bytes, got %d", BIP340MessageLength, len(pkg.Message))
+		}
+
+		if err := pkg.BIP340.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for i, commitment := range pkg.Commitments {
+		if commitment.Identifier == nil || commitment.Identifier.Group() != g || commitment.Identifier.IsZero() {
+			return fmt.Errorf("invalid identifier for %s", commitment.Party)
+		}
+
+		if i > 0 && pkg.Commitments[i-1].Identifier.BigInt().Cmp(commitment.Identifier.BigInt()) >= 0 {
+			return fmt.Errorf("signing commitments are not sorted by distinct identifiers")
+		}
+
+		hiding, binding := commitment.Commitment.Hiding, commitment.Commitment.Binding
+		if hiding == nil || binding == nil || hiding.Group() != g || binding.Group() != g || hiding.IsIdentity() || binding.IsIdentity() {
+			return fmt.Errorf("invalid nonce commitment for %s", commitment.Party)
+		}
+	}
+
+	return nil
+}
+
+// Identifiers returns the identifiers of every signer in the package.
+func (pkg SigningPackage) Identifiers() []*group.Scalar {
+	identifiers := make([]*group.Scalar, len(pkg.Commitments))
+	for i, commitment := range pkg.Commitments {
+		identifiers[i] = commitment.Identifier
+	}
+	return identifiers
+}
+
+// Commitment returns the nonce commitment listed for an identifier.
+func (pkg SigningPackage) Commitment(identifier *group.Scalar) (SigningCommitment, bool) {
+	for _, commitment := range pkg.Commitments {
+		if commitment.Identifier.Equal(identifier) {
+			return commitment, true
+		}
+	}
+	return SigningCommitment{}, false
+}
+
+// BindingFactorInput is SerializeElement(Y) || H4(msg) || H5(encoded commitment
+// list) || SerializeScalar(identifier), as in compute_binding_factors.
+func BindingFactorInput(cs Ciphersuite, groupPublicKey *group.Element, pkg SigningPackage, identifier *group.Scalar) []byte {
+	input := append([]byte{}, groupPublicKey.Bytes()...)
+	input = append(input, cs.H4(pkg.Message)...)
+	input = append(input, cs.H5(encodeCommitments(pkg))...)
+	return append(input, identifier.Bytes()...)
+}
+
+// BindingFactors computes ρ_i = H1(binding factor input) for every signer,
+// binding each signer's nonce to the message, the group key and the full
+// commitment list.
+func BindingFactors(cs Ciphersuite, groupPublicKey *group.Element, pkg SigningPackage) map[string]*group.Scalar {
+	factors := make(map[string]*group.Scalar, len(pkg.Commitments))
+	for _, commitment := range pkg.Commitments {
+		factors[commitment.Identifier.String()] = cs.H1(BindingFactorInput(cs, groupPublicKey, pkg, commitment.Identifier))
+	}
+
+	return factors
+}
+
+// GroupCommitment computes R = Σ D_i + ρ_i·E_i.
+func GroupCommitment(cs Ciphersuite, pkg SigningPackage, bindingFactors map[string]*group.Scalar) *group.Element {
+	R := cs.Group().Identity()
+	for _, commitment := range pkg.Commitments {
+		rho := bindingFactors[commitment.Identifier.String()]
+		R = R.Add(commitment.Commitment.Hiding).Add(commitment.Commitment.Binding.ScalarMult(rho))
+	}
+	return R
+}
+
+// Challenge computes c = H2(SerializeElement(R) || SerializeElement(Y) || m).
+func Challenge(cs Ciphersuite, R, groupPublicKey *group.Element, message []byte) *group.Scalar {
+	input := append([]byte{}, R.Bytes()...)
+	input = append(input, groupPublicKey.Bytes()...)
+	return cs.H2(append(input, message...))
+}
+
+// Sign computes the signer's share z_i = d_i + e_i·ρ_i + λ_i·s_i·c, with the
+// nonces and signing share negated as BIP-340 requires.
+func Sign(cs Ciphersuite, key KeyShare, nonce *Nonce, pkg SigningPackage) (*group.Scalar, error) {
+	if err := pkg.Validate(cs); err != nil {
+		return nil, err
+	}
+
+	own, ok := pkg.Commitment(key.Identifier)
+	if !ok {
+		return nil, fmt.Errorf("signer %s is not part of the signing package", key.Identifier)
+	}
+
+	if own.Commitment.Index != nonce.Index || !own.Commitment.Hiding.Equal(group.ScalarBaseMult(nonce.Hiding)) || !own.Commitment.Binding.Equal(group.ScalarBaseMult(nonce.Binding)) {
+		return nil, fmt.Errorf("signing package does not carry the signer's nonce commitment")
+	}
+
+	lambda, err := sss.LagrangeCoefficient(key.Identifier, pkg.Identifiers())
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := newSession(cs, key.GroupPublicKey, pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	z := nonce.Hiding.Add(nonce.Binding.Mul(s.bindingFactors[key.Identifier.String()])).Mul(s.nonceFactor)
+	return z.Add(lambda.Mul(key.SigningShare).Mul(s.keyFactor).Mul(s.c)), nil
+}
+
+// VerifySignatureShare checks z_i·G == D_i + ρ_i·E_i + λ_i·c·Y_i, with the
+// same sign flips as Sign.
+func VerifySignatureShare(cs Ciphersuite, identifier *group.Scalar, verificationShare *group.Element, z *group.Scalar, groupPublicKey *group.Element, pkg SigningPackage) error {
+	if z == nil || verificationShare == nil || z.Group() != cs.Group() || verificationShare.Group() != cs.Group() {
+		return ErrInvalidSignatureShare
+	}
+
+	commitment, ok := pkg.Commitment(identifier)
+	if !ok {
+		return fmt.Errorf("signer %s is not part of the signing package", identifier)
+	}
+
+	lambda, err := sss.LagrangeCoefficient(identifier, pkg.Identifiers())
+	if err != nil {
+		return err
+	}
+
+	s, err := newSession(cs, groupPublicKey, pkg)
+	if err != nil {
+		return err
+	}
+
+	expected := commitment.Commitment.Hiding.
+		Add(commitment.Commitment.Binding.ScalarMult(s.bindingFactors[identifier.String()])).
+		ScalarMult(s.nonceFactor).
+		Add(verificationShare.ScalarMult(lambda.Mul(s.keyFactor).Mul(s.c)))
+
+	if !group.ScalarBaseMult(z).Equal(expected) {
+		return ErrInvalidSignatureShare
+	}
+
+	return nil
+}
+
+// Aggregate sums the signature shares of every signer into (R, z). For BIP-340
+// R has an even Y coordinate and z includes the Taproot tweak.
+func Aggregate(cs Ciphersuite, groupPublicKey *group.Element, pkg SigningPackage, shares map[string]*group.Scalar) (Signature, error) {
+	if err := pkg.Validate(cs); err != nil {
+		return Signature{}, err
+	}
+
+	s, err := newSession(cs, groupPublicKey, pkg)
+	if err != nil {
+		return Signature{}, err
+	}
+
+	z := s.c.Mul(s.tweak)
+	for _, commitment := range pkg.Commitments {
+		share, ok := shares[commitment.Identifier.String()]
+		if !ok || share.Group() != cs.Group() {
+			return Signature{}, fmt.Errorf("missing signature share from %s", commitment.Party)
+		}
+		z = z.Add(share)
+	}
+
+	return Signature{
+		R: s.R.ScalarMult(s.nonceFactor),
+		Z: z,
+	}, nil
+}
+
+// Verify checks z·G == R + c·Y.
+func Verify(cs Ciphersuite, groupPublicKey *group.Element, message []byte, signature Signature) error {
+	if signature.R == nil || signature.Z == nil || signature.R.IsIdentity() {
+		return ErrInvalidSignature
+	}
+
+	g := cs.Group()
+	if groupPublicKey.Group() != g || signature.R.Group() != g || signature.Z.Group() != g {
+		return ErrInvalidSignature
+	}
+
+	c := Challenge(cs, signature.R, groupPublicKey, message)
+	if !group.ScalarBaseMult(signature.Z).Equal(signature.R.Add(groupPublicKey.ScalarMult(c))) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// newSession binds the package to the group key, or for BIP-340 to the even-Y
+// (and possibly tweaked) output key.
+func newSession(cs Ciphersuite, groupPublicKey *group.Element, pkg SigningPackage) (*session, error) {
+	g := cs.Group()
+	if groupPublicKey == nil || groupPublicKey.Group() != g {
+		return nil, fmt.Errorf("group key is not an element of %s", g.Name())
+	}
+
+	s := &session{
+		key:         groupPublicKey,
+		keyFactor:   group.ScalarFromInt(g, 1),
+		nonceFactor: group.ScalarFromInt(g, 1),
+		tweak:       group.ScalarFromInt(g, 0),
+	}
+
+	if pkg.BIP340 != nil {
+		output, err := pkg.BIP340.OutputKey(groupPublicKey)
+		if err != nil {
+			return nil, err
+		}
+		s.key, s.keyFactor, s.tweak = output.Key, output.KeyFactor, output.Tweak
+	}
+
+	s.bindingFactors = BindingFactors(cs, s.key, pkg)
+	s.R = GroupCommitment(cs, pkg, s.bindingFactors)
+
+	if pkg.BIP340 == nil {
+		s.c = Challenge(cs, s.R, s.key, pkg.Message)
+		return s, nil
+	}
+
+	if s.R.IsIdentity() {
+		return nil, ErrIdentityElement
+	}
+
+	_, s.nonceFactor = evenY(s.R)
+	s.c = BIP340Challenge(s.R, s.key, pkg.Message)
+	return s, nil
+}
+
+// encodeCommitments is encode_group_commitment_list: the identifier and both
+// nonce commitments of every signer, in identifier order.
+func encodeCommitments(pkg SigningPackage) []byte {
+	var encoded []byte
+	for _, commitment := range pkg.Commitments {
+		encoded = append(encoded, commitment.Identifier.Bytes()...)
+		encoded = append(encoded, commitment.Commitment.Hiding.Bytes()...)
+		encoded = append(encoded, commitment.Commitment.Binding.Bytes()...)
+	}
+	return encoded
+}
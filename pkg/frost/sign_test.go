@@ -0,0 +1,129 @@
+package frost_test
+
+import (
+	"fmt"
+	sss "frost/pkg/SSS"
+	"frost/pkg/frost"
+	"frost/pkg/group"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sign", func() {
+	for _, cs := range []frost.Ciphersuite{frost.Secp256k1SHA256, frost.Ed25519SHA512, frost.Ristretto255SHA512} {
+		cs := cs
+		g := cs.Group()
+
+		var (
+			groupPublicKey *group.Element
+			keys           []frost.KeyShare
+			nonces         []*frost.Nonce
+			pkg            frost.SigningPackage
+			message        = []byte("frost")
+		)
+
+		BeforeEach(func() {
+			secret, err := group.RandomScalar(g)
+			Expect(err).To(BeNil())
+			groupPublicKey = group.ScalarBaseMult(secret)
+
+			polynomial, err := sss.MakePolynomial(secret, 1)
+			Expect(err).To(BeNil())
+
+			shares, err := polynomial.Shares([]*group.Scalar{group.ScalarFromInt(g, 1), group.ScalarFromInt(g, 2), group.ScalarFromInt(g, 3)})
+			Expect(err).To(BeNil())
+
+			keys = nil
+			for _, share := range shares {
+				keys = append(keys, frost.KeyShare{Identifier: share.ID, SigningShare: share.Value, GroupPublicKey: groupPublicKey})
+			}
+
+			// parties 3 and 1 sign, listed out of order
+			nonces = nil
+			var commitments []frost.SigningCommitment
+			for _, key := range []frost.KeyShare{keys[2], keys[0]} {
+				nonce, err := frost.NewNonce(cs, 0, key.SigningShare)
+				Expect(err).To(BeNil())
+				nonces = append(nonces, nonce)
+				commitments = append(commitments, frost.SigningCommitment{
+					Party:      fmt.Sprintf("party-%s", key.Identifier),
+					Identifier: key.Identifier,
+					Commitment: nonce.Commit(),
+				})
+			}
+
+			pkg, err = frost.NewSigningPackage(cs, message, commitments)
+			Expect(err).To(BeNil())
+		})
+
+		sign := func() map[string]*group.Scalar {
+			shares := make(map[string]*group.Scalar)
+			for i, key := range []frost.KeyShare{keys[2], keys[0]} {
+				z, err := frost.Sign(cs, key, nonces[i], pkg)
+				Expect(err).To(BeNil())
+				shares[key.Identifier.String()] = z
+			}
+			return shares
+		}
+
+		Context("While signing with t of n parties over "+cs.ID(), func() {
+			It("should sort the commitment list by identifier", func() {
+				identifiers := pkg.Identifiers()
+				Expect(identifiers).To(HaveLen(2))
+				Expect(identifiers[0].Equal(keys[0].Identifier)).To(BeTrue())
+				Expect(identifiers[1].Equal(keys[2].Identifier)).To(BeTrue())
+			})
+
+			It("should produce shares that verify and aggregate into a valid signature", func() {
+				shares := sign()
+				for _, key := range []frost.KeyShare{keys[2], keys[0]} {
+					verificationShare := group.ScalarBaseMult(key.SigningShare)
+					Expect(frost.VerifySignatureShare(cs, key.Identifier, verificationShare, shares[key.Identifier.String()], groupPublicKey, pkg)).To(Succeed())
+				}
+
+				signature, err := frost.Aggregate(cs, groupPublicKey, pkg, shares)
+				Expect(err).To(BeNil())
+				Expect(frost.Verify(cs, groupPublicKey, message, signature)).To(Succeed())
+				Expect(frost.Verify(cs, groupPublicKey, []byte("other"), signature)).To(MatchError(frost.ErrInvalidSignature))
+
+				encoded, err := signature.Encode()
+				Expect(err).To(BeNil())
+				decoded, err := frost.DecodeSignature(cs, encoded)
+				Expect(err).To(BeNil())
+				Expect(frost.Verify(cs, groupPublicKey, message, decoded)).To(Succeed())
+			})
+
+			It("should flag a tampered signature share", func() {
+				shares := sign()
+				id := keys[0].Identifier.String()
+				shares[id] = shares[id].Add(group.ScalarFromInt(g, 1))
+
+				err := frost.VerifySignatureShare(cs, keys[0].Identifier, group.ScalarBaseMult(keys[0].SigningShare), shares[id], groupPublicKey, pkg)
+				Expect(err).To(MatchError(frost.ErrInvalidSignatureShare))
+
+				signature, err := frost.Aggregate(cs, groupPublicKey, pkg, shares)
+				Expect(err).To(BeNil())
+				Expect(frost.Verify(cs, groupPublicKey, message, signature)).To(MatchError(frost.ErrInvalidSignature))
+			})
+
+			It("should refuse to sign with a nonce that is not in the package", func() {
+				other, err := frost.NewNonce(cs, 0, keys[0].SigningShare)
+				Expect(err).To(BeNil())
+
+				_, err = frost.Sign(cs, keys[0], other, pkg)
+				Expect(err).ToNot(BeNil())
+			})
+
+			It("should refuse to sign for a party outside the package", func() {
+				_, err := frost.Sign(cs, keys[1], nonces[0], pkg)
+				Expect(err).ToNot(BeNil())
+			})
+
+			It("should reject duplicate signers", func() {
+				_, err := frost.NewSigningPackage(cs, message, []frost.SigningCommitment{pkg.Commitments[0], pkg.Commitments[0]})
+				Expect(err).ToNot(BeNil())
+			})
+		})
+	}
+})
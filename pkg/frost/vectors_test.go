@@ -0,0 +1,187 @@
+package frost_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	sss "frost/pkg/SSS"
+	"frost/pkg/frost"
+	"frost/pkg/group"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// vectors mirrors the layout of the RFC 9591 reference test vectors.
+type vectors struct {
+	Inputs struct {
+		ParticipantList             []int64  `json:"participant_list"`
+		GroupSecretKey              string   `json:"group_secret_key"`
+		GroupPublicKey              string   `json:"group_public_key"`
+		Message                     string   `json:"message"`
+		SharePolynomialCoefficients []string `json:"share_polynomial_coefficients"`
+		ParticipantShares           []struct {
+			Identifier       int64  `json:"identifier"`
+			ParticipantShare string `json:"participant_share"`
+		} `json:"participant_shares"`
+	} `json:"inputs"`
+	RoundOneOutputs struct {
+		Outputs []struct {
+			Identifier             int64  `json:"identifier"`
+			HidingNonceRandomness  string `json:"hiding_nonce_randomness"`
+			BindingNonceRandomness string `json:"binding_nonce_randomness"`
+			HidingNonce            string `json:"hiding_nonce"`
+			BindingNonce           string `json:"binding_nonce"`
+			HidingNonceCommitment  string `json:"hiding_nonce_commitment"`
+			BindingNonceCommitment string `json:"binding_nonce_commitment"`
+			BindingFactorInput     string `json:"binding_factor_input"`
+			BindingFactor          string `json:"binding_factor"`
+		} `json:"outputs"`
+	} `json:"round_one_outputs"`
+	RoundTwoOutputs struct {
+		Outputs []struct {
+			Identifier int64  `json:"identifier"`
+			SigShare   string `json:"sig_share"`
+		} `json:"outputs"`
+	} `json:"round_two_outputs"`
+	FinalOutput struct {
+		Sig string `json:"sig"`
+	} `json:"final_output"`
+}
+
+var _ = Describe("RFC 9591 test vectors", func() {
+	for _, suite := range []struct {
+		cs   frost.Ciphersuite
+		file string
+	}{
+		{frost.Secp256k1SHA256, "testdata/frost_secp256k1_sha256.json"},
+		{frost.Ed25519SHA512, "testdata/frost_ed25519_sha512.json"},
+		{frost.Ristretto255SHA512, "testdata/frost_ristretto255_sha512.json"},
+	} {
+		suite := suite
+		cs := suite.cs
+		g := cs.Group()
+
+		Context("While signing over "+cs.ID(), func() {
+			var (
+				v              vectors
+				message        []byte
+				groupPublicKey *group.Element
+				shares         map[int64]*group.Scalar
+				nonces         map[int64]*frost.Nonce
+				pkg            frost.SigningPackage
+			)
+
+			decode := func(s string) []byte {
+				b, err := hex.DecodeString(s)
+				Expect(err).To(BeNil())
+				return b
+			}
+
+			scalar := func(s string) *group.Scalar {
+				k, err := group.DeserializeScalar(g, decode(s))
+				Expect(err).To(BeNil())
+				return k
+			}
+
+			element := func(s string) *group.Element {
+				p, err := frost.DeserializeElement(cs, decode(s))
+				Expect(err).To(BeNil())
+				return p
+			}
+
+			BeforeEach(func() {
+				data, err := os.ReadFile(suite.file)
+				Expect(err).To(BeNil())
+				Expect(json.Unmarshal(data, &v)).To(Succeed())
+
+				message = decode(v.Inputs.Message)
+				groupPublicKey = element(v.Inputs.GroupPublicKey)
+
+				shares = make(map[int64]*group.Scalar)
+				for _, share := range v.Inputs.ParticipantShares {
+					shares[share.Identifier] = scalar(share.ParticipantShare)
+				}
+
+				nonces = make(map[int64]*frost.Nonce)
+				var commitments []frost.SigningCommitment
+				for _, output := range v.RoundOneOutputs.Outputs {
+					nonce := &frost.Nonce{
+						Hiding:  frost.DeriveNonce(cs, decode(output.HidingNonceRandomness), shares[output.Identifier]),
+						Binding: frost.DeriveNonce(cs, decode(output.BindingNonceRandomness), shares[output.Identifier]),
+					}
+					nonces[output.Identifier] = nonce
+					commitments = append(commitments, frost.SigningCommitment{
+						Party:      fmt.Sprint(output.Identifier),
+						Identifier: group.ScalarFromInt(g, output.Identifier),
+						Commitment: nonce.Commit(),
+					})
+				}
+
+				pkg, err = frost.NewSigningPackage(cs, message, commitments)
+				Expect(err).To(BeNil())
+			})
+
+			It("should derive the participant shares and group key from the dealer polynomial", func() {
+				polynomial := sss.Polynomial{scalar(v.Inputs.GroupSecretKey)}
+				for _, coefficient := range v.Inputs.SharePolynomialCoefficients {
+					polynomial = append(polynomial, scalar(coefficient))
+				}
+
+				Expect(polynomial.Commit().PublicKey().Equal(groupPublicKey)).To(BeTrue())
+				for id, share := range shares {
+					Expect(polynomial.Evaluate(group.ScalarFromInt(g, id)).Equal(share)).To(BeTrue())
+				}
+			})
+
+			It("should derive the nonces, commitments and binding factors of round one", func() {
+				factors := frost.BindingFactors(cs, groupPublicKey, pkg)
+
+				for _, output := range v.RoundOneOutputs.Outputs {
+					identifier := group.ScalarFromInt(g, output.Identifier)
+					nonce := nonces[output.Identifier]
+					Expect(nonce.Hiding.Equal(scalar(output.HidingNonce))).To(BeTrue())
+					Expect(nonce.Binding.Equal(scalar(output.BindingNonce))).To(BeTrue())
+
+					commitment := nonce.Commit()
+					Expect(commitment.Hiding.Equal(element(output.HidingNonceCommitment))).To(BeTrue())
+					Expect(commitment.Binding.Equal(element(output.BindingNonceCommitment))).To(BeTrue())
+
+					Expect(frost.BindingFactorInput(cs, groupPublicKey, pkg, identifier)).To(Equal(decode(output.BindingFactorInput)))
+					Expect(factors[identifier.String()].Equal(scalar(output.BindingFactor))).To(BeTrue())
+				}
+			})
+
+			It("should produce the signature shares and signature of round two", func() {
+				sigShares := make(map[string]*group.Scalar)
+				for _, output := range v.RoundTwoOutputs.Outputs {
+					key := frost.KeyShare{
+						Identifier:     group.ScalarFromInt(g, output.Identifier),
+						SigningShare:   shares[output.Identifier],
+						GroupPublicKey: groupPublicKey,
+					}
+
+					z, err := frost.Sign(cs, key, nonces[output.Identifier], pkg)
+					Expect(err).To(BeNil())
+					Expect(z.Equal(scalar(output.SigShare))).To(BeTrue())
+
+					verificationShare := group.ScalarBaseMult(key.SigningShare)
+					Expect(frost.VerifySignatureShare(cs, key.Identifier, verificationShare, z, groupPublicKey, pkg)).To(Succeed())
+					sigShares[key.Identifier.String()] = z
+				}
+
+				signature, err := frost.Aggregate(cs, groupPublicKey, pkg, sigShares)
+				Expect(err).To(BeNil())
+
+				encoded, err := signature.Encode()
+				Expect(err).To(BeNil())
+				Expect(hex.EncodeToString(encoded)).To(Equal(v.FinalOutput.Sig))
+
+				decoded, err := frost.DecodeSignature(cs, encoded)
+				Expect(err).To(BeNil())
+				Expect(frost.Verify(cs, groupPublicKey, message, decoded)).To(Succeed())
+			})
+		})
+	}
+})
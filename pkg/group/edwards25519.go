@@ -0,0 +1,108 @@
+package group
+
+import (
+	"fmt"
+	"math/big"
+
+	"filippo.io/edwards25519"
+)
+
+// ed25519Order is l = 2^252 + 27742317777372353535851937790883648493, the
+// order of the prime-order subgroup of edwards25519.
+var ed25519Order, _ = new(big.Int).SetString("7237005577332262213973186563042994240857116359379907606001950938285454250989", 10)
+
+// Edwards25519 is the prime-order subgroup of edwards25519 with the RFC 8032
+// point encoding and 32 byte little-endian scalars.
+var Edwards25519 Group = register(edwards25519Group{})
+
+type edwards25519Group struct{}
+
+func (edwards25519Group) Name() string       { return "edwards25519" }
+func (edwards25519Group) Order() *big.Int    { return ed25519Order }
+func (edwards25519Group) ScalarLength() int  { return 32 }
+func (edwards25519Group) ElementLength() int { return 32 }
+
+func (edwards25519Group) Identity() *Element {
+	return &Element{group: Edwards25519, point: &edwards25519Point{p: edwards25519.NewIdentityPoint()}}
+}
+
+func (edwards25519Group) Generator() *Element {
+	return &Element{group: Edwards25519, point: &edwards25519Point{p: edwards25519.NewGeneratorPoint()}}
+}
+
+func (edwards25519Group) EncodeScalar(k *big.Int) []byte {
+	return reverse(k.FillBytes(make([]byte, 32)))
+}
+
+func (edwards25519Group) DecodeScalar(b []byte) (*big.Int, error) {
+	k := new(big.Int).SetBytes(reverse(b))
+	if k.Cmp(ed25519Order) >= 0 {
+		return nil, fmt.Errorf("scalar is not reduced modulo the group order")
+	}
+	return k, nil
+}
+
+// DecodeElement accepts only canonical encodings of points in the prime-order
+// subgroup, so small-order components can never be smuggled into a key.
+func (g edwards25519Group) DecodeElement(b []byte) (*Element, error) {
+	p, err := new(edwards25519.Point).SetBytes(b)
+	if err != nil {
+		return nil, err
+	}
+
+	point := &edwards25519Point{p: p}
+	if string(point.bytes()) != string(b) {
+		return nil, fmt.Errorf("non-canonical point encoding")
+	}
+
+	// l·P = (l-1)·P + P is the identity exactly for points of order l
+	lMinusOne := new(big.Int).Sub(ed25519Order, big.NewInt(1))
+	if !point.scalarMult(lMinusOne).add(point).isIdentity() {
+		return nil, fmt.Errorf("point is not in the prime-order subgroup")
+	}
+
+	return &Element{group: Edwards25519, point: point}, nil
+}
+
+type edwards25519Point struct {
+	p *edwards25519.Point
+}
+
+func (p *edwards25519Point) isIdentity() bool {
+	return p.p.Equal(edwards25519.NewIdentityPoint()) == 1
+}
+
+func (p *edwards25519Point) add(e element) element {
+	return &edwards25519Point{p: new(edwards25519.Point).Add(p.p, e.(*edwards25519Point).p)}
+}
+
+func (p *edwards25519Point) negate() element {
+	return &edwards25519Point{p: new(edwards25519.Point).Negate(p.p)}
+}
+
+func (p *edwards25519Point) scalarMult(k *big.Int) element {
+	s, err := edwards25519.NewScalar().SetCanonicalBytes(Edwards25519.EncodeScalar(new(big.Int).Mod(k, ed25519Order)))
+	if err != nil {
+		// unreachable, k is reduced above
+		panic(err)
+	}
+	return &edwards25519Point{p: new(edwards25519.Point).ScalarMult(s, p.p)}
+}
+
+func (p *edwards25519Point) equal(e element) bool {
+	return p.p.Equal(e.(*edwards25519Point).p) == 1
+}
+
+func (p *edwards25519Point) bytes() []byte {
+	return p.p.Bytes()
+}
+
+// reverse returns b in reverse byte order, converting between the big-endian
+// encoding of big.Int and little-endian scalar encodings.
+func reverse(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[len(b)-1-i] = b[i]
+	}
+	return out
+}
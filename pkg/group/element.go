@@ -0,0 +1,89 @@
+package group
+
+import (
+	"encoding/hex"
+	"encoding/json"
+)
+
+// Element is a member of a prime-order group. Operations return new elements
+// and panic when mixing elements of different groups.
+type Element struct {
+	group Group
+	point element
+}
+
+// ScalarBaseMult returns k·G.
+func ScalarBaseMult(k *Scalar) *Element {
+	return k.group.Generator().ScalarMult(k)
+}
+
+// DeserializeElement parses the group's element encoding.
+func DeserializeElement(g Group, b []byte) (*Element, error) {
+	return g.DecodeElement(b)
+}
+
+func (p *Element) Group() Group {
+	return p.group
+}
+
+// Add returns p + q.
+func (p *Element) Add(q *Element) *Element {
+	mustMatch(p.group, q.group)
+	return &Element{group: p.group, point: p.point.add(q.point)}
+}
+
+// Sub returns p - q.
+func (p *Element) Sub(q *Element) *Element {
+	return p.Add(q.Negate())
+}
+
+// Negate returns -p.
+func (p *Element) Negate() *Element {
+	return &Element{group: p.group, point: p.point.negate()}
+}
+
+// ScalarMult returns k·p.
+func (p *Element) ScalarMult(k *Scalar) *Element {
+	mustMatch(p.group, k.group)
+	return &Element{group: p.group, point: p.point.scalarMult(k.value)}
+}
+
+func (p *Element) IsIdentity() bool {
+	return p.point.isIdentity()
+}
+
+func (p *Element) Equal(q *Element) bool {
+	if p == nil || q == nil {
+		return p == q
+	}
+	return p.group == q.group && p.point.equal(q.point)
+}
+
+// Bytes returns the group's element encoding.
+func (p *Element) Bytes() []byte {
+	return p.point.bytes()
+}
+
+func (p *Element) String() string {
+	return hex.EncodeToString(p.Bytes())
+}
+
+// MarshalJSON encodes the element as "<group>:<hex>".
+func (p *Element) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.group.Name() + ":" + p.String())
+}
+
+func (p *Element) UnmarshalJSON(data []byte) error {
+	g, b, err := decodeJSON(data)
+	if err != nil {
+		return err
+	}
+
+	element, err := DeserializeElement(g, b)
+	if err != nil {
+		return err
+	}
+
+	*p = *element
+	return nil
+}
@@ -0,0 +1,62 @@
+// prime-order groups the threshold protocols run over
+package group
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Group is a prime-order group together with its scalar field. Scalar
+// arithmetic is shared by every group, elements delegate to the group's own
+// point representation.
+type Group interface {
+	// Name identifies the group in serialized scalars and elements.
+	Name() string
+	// Order is the prime order of the group.
+	Order() *big.Int
+
+	ScalarLength() int
+	ElementLength() int
+
+	Identity() *Element
+	Generator() *Element
+
+	// EncodeScalar returns the ScalarLength byte encoding of k < Order.
+	EncodeScalar(k *big.Int) []byte
+	// DecodeScalar parses a scalar encoding, rejecting values >= Order.
+	DecodeScalar(b []byte) (*big.Int, error)
+	// DecodeElement parses an element encoding, accepting the identity.
+	DecodeElement(b []byte) (*Element, error)
+}
+
+// element is a group specific point representation.
+type element interface {
+	add(element) element
+	negate() element
+	scalarMult(k *big.Int) element
+	isIdentity() bool
+	equal(element) bool
+	bytes() []byte
+}
+
+var groups = make(map[string]Group)
+
+func register(g Group) Group {
+	groups[g.Name()] = g
+	return g
+}
+
+// ByName returns a registered group.
+func ByName(name string) (Group, error) {
+	g, ok := groups[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown group %s", name)
+	}
+	return g, nil
+}
+
+func mustMatch(a, b Group) {
+	if a != b {
+		panic(fmt.Sprintf("mixing values of groups %s and %s", a.Name(), b.Name()))
+	}
+}
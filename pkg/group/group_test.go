@@ -0,0 +1,93 @@
+package group_test
+
+import (
+	"encoding/json"
+	"frost/pkg/group"
+	"math/big"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Group", func() {
+	for _, g := range []group.Group{group.Secp256k1, group.Edwards25519, group.Ristretto255} {
+		g := g
+
+		Context("While doing arithmetic in "+g.Name(), func() {
+			It("should agree between scalars and elements", func() {
+				a, err := group.RandomScalar(g)
+				Expect(err).To(BeNil())
+				b, err := group.RandomScalar(g)
+				Expect(err).To(BeNil())
+
+				sum := group.ScalarBaseMult(a).Add(group.ScalarBaseMult(b))
+				Expect(sum.Equal(group.ScalarBaseMult(a.Add(b)))).To(BeTrue())
+				Expect(group.ScalarBaseMult(a).ScalarMult(b).Equal(group.ScalarBaseMult(a.Mul(b)))).To(BeTrue())
+				Expect(sum.Sub(group.ScalarBaseMult(b)).Equal(group.ScalarBaseMult(a))).To(BeTrue())
+				Expect(group.ScalarBaseMult(a).Add(group.ScalarBaseMult(a.Negate())).IsIdentity()).To(BeTrue())
+
+				inverse, err := a.Invert()
+				Expect(err).To(BeNil())
+				Expect(a.Mul(inverse).Equal(group.ScalarFromInt(g, 1))).To(BeTrue())
+			})
+
+			It("should reduce scalars modulo the group order", func() {
+				n := new(big.Int).Add(g.Order(), big.NewInt(5))
+				Expect(group.NewScalar(g, n).Equal(group.ScalarFromInt(g, 5))).To(BeTrue())
+				Expect(group.ScalarFromInt(g, -1).Add(group.ScalarFromInt(g, 1)).IsZero()).To(BeTrue())
+
+				_, err := group.DeserializeScalar(g, g.EncodeScalar(new(big.Int).Sub(g.Order(), big.NewInt(1))))
+				Expect(err).To(BeNil())
+			})
+
+			It("should round trip scalars and elements", func() {
+				k, err := group.RandomScalar(g)
+				Expect(err).To(BeNil())
+				p := group.ScalarBaseMult(k)
+
+				decodedScalar, err := group.DeserializeScalar(g, k.Bytes())
+				Expect(err).To(BeNil())
+				Expect(decodedScalar.Equal(k)).To(BeTrue())
+
+				for _, q := range []*group.Element{p, p.Negate(), g.Identity()} {
+					decoded, err := group.DeserializeElement(g, q.Bytes())
+					Expect(err).To(BeNil())
+					Expect(decoded.Equal(q)).To(BeTrue())
+				}
+
+				data, err := json.Marshal(struct {
+					K *group.Scalar  `json:"k"`
+					P *group.Element `json:"p"`
+				}{k, p})
+				Expect(err).To(BeNil())
+
+				var decoded struct {
+					K *group.Scalar  `json:"k"`
+					P *group.Element `json:"p"`
+				}
+				Expect(json.Unmarshal(data, &decoded)).To(Succeed())
+				Expect(decoded.K.Equal(k)).To(BeTrue())
+				Expect(decoded.P.Equal(p)).To(BeTrue())
+			})
+		})
+	}
+
+	Context("While encoding secp256k1 points", func() {
+		It("should reject points off the curve", func() {
+			// x = 5 has no y with y² = x³ + 7 on secp256k1
+			b := make([]byte, 33)
+			b[0], b[32] = 0x02, 0x05
+			_, err := group.DeserializeElement(group.Secp256k1, b)
+			Expect(err).ToNot(BeNil())
+		})
+
+		It("should reject unknown groups and unreduced scalars", func() {
+			var k group.Scalar
+			Expect(json.Unmarshal([]byte(`"p256:01"`), &k)).ToNot(Succeed())
+
+			order := group.Secp256k1.Order().FillBytes(make([]byte, 32))
+			_, err := group.DeserializeScalar(group.Secp256k1, order)
+			Expect(err).ToNot(BeNil())
+		})
+	})
+})
@@ -0,0 +1,81 @@
+package group
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/gtank/ristretto255"
+)
+
+// Ristretto255 is the ristretto255 prime-order group of RFC 9496, with 32 byte
+// canonical element encodings and 32 byte little-endian scalars. Every valid
+// encoding is a prime-order element, so there is no cofactor to clear.
+var Ristretto255 Group = register(ristretto255Group{})
+
+type ristretto255Group struct{}
+
+func (ristretto255Group) Name() string       { return "ristretto255" }
+func (ristretto255Group) Order() *big.Int    { return ed25519Order }
+func (ristretto255Group) ScalarLength() int  { return 32 }
+func (ristretto255Group) ElementLength() int { return 32 }
+
+func (ristretto255Group) Identity() *Element {
+	return &Element{group: Ristretto255, point: &ristretto255Point{p: ristretto255.NewElement().Zero()}}
+}
+
+func (ristretto255Group) Generator() *Element {
+	return &Element{group: Ristretto255, point: &ristretto255Point{p: ristretto255.NewElement().Base()}}
+}
+
+func (ristretto255Group) EncodeScalar(k *big.Int) []byte {
+	return reverse(k.FillBytes(make([]byte, 32)))
+}
+
+func (ristretto255Group) DecodeScalar(b []byte) (*big.Int, error) {
+	k := new(big.Int).SetBytes(reverse(b))
+	if k.Cmp(ed25519Order) >= 0 {
+		return nil, fmt.Errorf("scalar is not reduced modulo the group order")
+	}
+	return k, nil
+}
+
+func (ristretto255Group) DecodeElement(b []byte) (*Element, error) {
+	p := ristretto255.NewElement()
+	if err := p.Decode(b); err != nil {
+		return nil, err
+	}
+	return &Element{group: Ristretto255, point: &ristretto255Point{p: p}}, nil
+}
+
+type ristretto255Point struct {
+	p *ristretto255.Element
+}
+
+func (p *ristretto255Point) isIdentity() bool {
+	return p.p.Equal(ristretto255.NewElement().Zero()) == 1
+}
+
+func (p *ristretto255Point) add(e element) element {
+	return &ristretto255Point{p: ristretto255.NewElement().Add(p.p, e.(*ristretto255Point).p)}
+}
+
+func (p *ristretto255Point) negate() element {
+	return &ristretto255Point{p: ristretto255.NewElement().Negate(p.p)}
+}
+
+func (p *ristretto255Point) scalarMult(k *big.Int) element {
+	s := ristretto255.NewScalar()
+	if err := s.Decode(Ristretto255.EncodeScalar(new(big.Int).Mod(k, ed25519Order))); err != nil {
+		// unreachable, k is reduced above
+		panic(err)
+	}
+	return &ristretto255Point{p: ristretto255.NewElement().ScalarMult(s, p.p)}
+}
+
+func (p *ristretto255Point) equal(e element) bool {
+	return p.p.Equal(e.(*ristretto255Point).p) == 1
+}
+
+func (p *ristretto255Point) bytes() []byte {
+	return p.p.Encode(nil)
+}
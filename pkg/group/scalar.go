@@ -0,0 +1,157 @@
+package group
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Scalar is an integer modulo the order of a group. Operations return new
+// scalars and panic when mixing scalars of different groups.
+type Scalar struct {
+	group Group
+	value *big.Int
+}
+
+// NewScalar returns v mod the group order.
+func NewScalar(g Group, v *big.Int) *Scalar {
+	return &Scalar{group: g, value: new(big.Int).Mod(v, g.Order())}
+}
+
+// ScalarFromInt returns v mod the group order.
+func ScalarFromInt(g Group, v int64) *Scalar {
+	return NewScalar(g, big.NewInt(v))
+}
+
+// RandomScalar returns a uniformly random non-zero scalar.
+func RandomScalar(g Group) (*Scalar, error) {
+	max := new(big.Int).Sub(g.Order(), big.NewInt(1))
+	k, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return nil, err
+	}
+	return NewScalar(g, k.Add(k, big.NewInt(1))), nil
+}
+
+// DeserializeScalar parses the group's scalar encoding.
+func DeserializeScalar(g Group, b []byte) (*Scalar, error) {
+	if len(b) != g.ScalarLength() {
+		return nil, fmt.Errorf("invalid scalar length %d", len(b))
+	}
+
+	v, err := g.DecodeScalar(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Scalar{group: g, value: v}, nil
+}
+
+func (s *Scalar) Group() Group {
+	return s.group
+}
+
+// Add returns s + t.
+func (s *Scalar) Add(t *Scalar) *Scalar {
+	mustMatch(s.group, t.group)
+	return NewScalar(s.group, new(big.Int).Add(s.value, t.value))
+}
+
+// Sub returns s - t.
+func (s *Scalar) Sub(t *Scalar) *Scalar {
+	mustMatch(s.group, t.group)
+	return NewScalar(s.group, new(big.Int).Sub(s.value, t.value))
+}
+
+// Mul returns s·t.
+func (s *Scalar) Mul(t *Scalar) *Scalar {
+	mustMatch(s.group, t.group)
+	return NewScalar(s.group, new(big.Int).Mul(s.value, t.value))
+}
+
+// Negate returns -s.
+func (s *Scalar) Negate() *Scalar {
+	return NewScalar(s.group, new(big.Int).Neg(s.value))
+}
+
+// Invert returns s⁻¹.
+func (s *Scalar) Invert() (*Scalar, error) {
+	inverse := new(big.Int).ModInverse(s.value, s.group.Order())
+	if inverse == nil {
+		return nil, fmt.Errorf("scalar is not invertible")
+	}
+	return &Scalar{group: s.group, value: inverse}, nil
+}
+
+func (s *Scalar) IsZero() bool {
+	return s.value.Sign() == 0
+}
+
+func (s *Scalar) Equal(t *Scalar) bool {
+	if s == nil || t == nil {
+		return s == t
+	}
+	return s.group == t.group && s.value.Cmp(t.value) == 0
+}
+
+// BigInt returns a copy of the scalar as an integer in [0, order).
+func (s *Scalar) BigInt() *big.Int {
+	return new(big.Int).Set(s.value)
+}
+
+// Bytes returns the group's scalar encoding.
+func (s *Scalar) Bytes() []byte {
+	return s.group.EncodeScalar(s.value)
+}
+
+func (s *Scalar) String() string {
+	return hex.EncodeToString(s.Bytes())
+}
+
+// MarshalJSON encodes the scalar as "<group>:<hex>".
+func (s *Scalar) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.group.Name() + ":" + s.String())
+}
+
+func (s *Scalar) UnmarshalJSON(data []byte) error {
+	g, b, err := decodeJSON(data)
+	if err != nil {
+		return err
+	}
+
+	scalar, err := DeserializeScalar(g, b)
+	if err != nil {
+		return err
+	}
+
+	*s = *scalar
+	return nil
+}
+
+// decodeJSON splits a "<group>:<hex>" JSON string.
+func decodeJSON(data []byte) (Group, []byte, error) {
+	var encoded string
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, nil, err
+	}
+
+	name, value, ok := strings.Cut(encoded, ":")
+	if !ok {
+		return nil, nil, fmt.Errorf("missing group in %q", encoded)
+	}
+
+	g, err := ByName(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b, err := hex.DecodeString(value)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return g, b, nil
+}
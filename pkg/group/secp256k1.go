@@ -0,0 +1,135 @@
+package group
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+)
+
+var curve = secp256k1.S256()
+
+// Secp256k1 is the secp256k1 group with SEC1 compressed elements and 32 byte
+// big-endian scalars. The identity is encoded as a single zero byte.
+var Secp256k1 Group = register(secp256k1Group{})
+
+type secp256k1Group struct{}
+
+func (secp256k1Group) Name() string       { return "secp256k1" }
+func (secp256k1Group) Order() *big.Int    { return curve.N }
+func (secp256k1Group) ScalarLength() int  { return 32 }
+func (secp256k1Group) ElementLength() int { return 33 }
+
+func (g secp256k1Group) Identity() *Element {
+	return &Element{group: Secp256k1, point: &secp256k1Point{x: new(big.Int), y: new(big.Int)}}
+}
+
+func (g secp256k1Group) Generator() *Element {
+	return &Element{group: Secp256k1, point: &secp256k1Point{x: new(big.Int).Set(curve.Gx), y: new(big.Int).Set(curve.Gy)}}
+}
+
+func (secp256k1Group) EncodeScalar(k *big.Int) []byte {
+	return k.FillBytes(make([]byte, 32))
+}
+
+func (secp256k1Group) DecodeScalar(b []byte) (*big.Int, error) {
+	k := new(big.Int).SetBytes(b)
+	if k.Cmp(curve.N) >= 0 {
+		return nil, fmt.Errorf("scalar is not reduced modulo the group order")
+	}
+	return k, nil
+}
+
+func (g secp256k1Group) DecodeElement(b []byte) (*Element, error) {
+	if len(b) == 1 && b[0] == 0x00 {
+		return g.Identity(), nil
+	}
+
+	if len(b) != 33 || (b[0] != 0x02 && b[0] != 0x03) {
+		return nil, fmt.Errorf("invalid compressed point encoding")
+	}
+
+	x := new(big.Int).SetBytes(b[1:])
+	if x.Cmp(curve.P) >= 0 {
+		return nil, fmt.Errorf("point x coordinate out of range")
+	}
+
+	// y² = x³ + 7, and since P ≡ 3 mod 4 the root is (y²)^((P+1)/4)
+	y2 := new(big.Int).Exp(x, big.NewInt(3), curve.P)
+	y2.Add(y2, curve.B).Mod(y2, curve.P)
+
+	exp := new(big.Int).Add(curve.P, big.NewInt(1))
+	exp.Rsh(exp, 2)
+	y := new(big.Int).Exp(y2, exp, curve.P)
+
+	if new(big.Int).Exp(y, big.NewInt(2), curve.P).Cmp(y2) != 0 {
+		return nil, fmt.Errorf("point is not on curve")
+	}
+
+	if y.Bit(0) != uint(b[0]&0x01) {
+		y.Sub(curve.P, y)
+	}
+
+	return &Element{group: Secp256k1, point: &secp256k1Point{x: x, y: y}}, nil
+}
+
+// secp256k1Point is an affine point. The point at infinity is (0, 0).
+type secp256k1Point struct {
+	x, y *big.Int
+}
+
+func (p *secp256k1Point) isIdentity() bool {
+	return p.x.Sign() == 0 && p.y.Sign() == 0
+}
+
+func (p *secp256k1Point) add(e element) element {
+	q := e.(*secp256k1Point)
+	if p.isIdentity() {
+		return q.clone()
+	}
+	if q.isIdentity() {
+		return p.clone()
+	}
+	x, y := curve.Add(p.x, p.y, q.x, q.y)
+	return &secp256k1Point{x: x, y: y}
+}
+
+func (p *secp256k1Point) negate() element {
+	if p.isIdentity() {
+		return p.clone()
+	}
+	return &secp256k1Point{x: new(big.Int).Set(p.x), y: new(big.Int).Sub(curve.P, p.y)}
+}
+
+func (p *secp256k1Point) scalarMult(k *big.Int) element {
+	if k.Sign() == 0 || p.isIdentity() {
+		return &secp256k1Point{x: new(big.Int), y: new(big.Int)}
+	}
+
+	x, y := curve.ScalarMult(p.x, p.y, k.Bytes())
+	if x == nil || y == nil {
+		return &secp256k1Point{x: new(big.Int), y: new(big.Int)}
+	}
+	return &secp256k1Point{x: x, y: y}
+}
+
+func (p *secp256k1Point) equal(e element) bool {
+	q := e.(*secp256k1Point)
+	return p.x.Cmp(q.x) == 0 && p.y.Cmp(q.y) == 0
+}
+
+// bytes returns the SEC1 compressed encoding of p.
+func (p *secp256k1Point) bytes() []byte {
+	if p.isIdentity() {
+		return []byte{0x00}
+	}
+
+	out := make([]byte, 33)
+	out[0] = 0x02 | byte(p.y.Bit(0))
+	p.x.FillBytes(out[1:])
+	return out
+}
+
+func (p *secp256k1Point) clone() *secp256k1Point {
+	return &secp256k1Point{x: new(big.Int).Set(p.x), y: new(big.Int).Set(p.y)}
+}